@@ -0,0 +1,144 @@
+package bcbp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BaggageUnit is the unit of a BaggageAllowance.
+type BaggageUnit string
+
+const (
+	// Kilograms is a weight in kilograms.
+	Kilograms BaggageUnit = "K"
+
+	// Pounds is a weight in pounds.
+	Pounds BaggageUnit = "L"
+
+	// Pieces is a number of pieces of baggage.
+	Pieces BaggageUnit = "PC"
+)
+
+// kilogramsPerPound is the number of kilograms in a pound.
+const kilogramsPerPound = 0.45359237
+
+// String returns the name of the unit.
+func (u BaggageUnit) String() string {
+	switch u {
+	case Kilograms:
+		return "kg"
+	case Pounds:
+		return "lb"
+	case Pieces:
+		return "pieces"
+	}
+	return "Unknown"
+}
+
+// BaggageAllowance is the free baggage allowance of a passenger. It is either
+// a weight in Kilograms or Pounds, or a number of Pieces.
+type BaggageAllowance struct {
+	Amount int
+	Unit   BaggageUnit
+}
+
+// ParseBaggageAllowance parses s as the Free Baggage Allowance item, e.g. 20K,
+// 40L, or 2PC. The unit is case insensitive and s may be padded with
+// whitespaces.
+func ParseBaggageAllowance(s string) (BaggageAllowance, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+
+	var a BaggageAllowance
+	switch {
+	case len(v) == 3 && strings.HasSuffix(v, string(Pieces)):
+		a.Unit = Pieces
+	case len(v) == 3 && strings.HasSuffix(v, string(Kilograms)):
+		a.Unit = Kilograms
+	case len(v) == 3 && strings.HasSuffix(v, string(Pounds)):
+		a.Unit = Pounds
+	default:
+		return BaggageAllowance{}, fmt.Errorf("bcbp: invalid free baggage allowance %q", s)
+	}
+
+	digits := strings.TrimSuffix(v, string(a.Unit))
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return BaggageAllowance{}, fmt.Errorf("bcbp: invalid free baggage allowance %q", s)
+		}
+	}
+	a.Amount, _ = strconv.Atoi(digits)
+	return a, nil
+}
+
+// Valid reports whether a can be encoded as the Free Baggage Allowance item.
+// That is, a weight between 0 and 99 or a number of pieces between 0 and 9.
+func (a BaggageAllowance) Valid() bool {
+	switch a.Unit {
+	case Kilograms, Pounds:
+		return a.Amount >= 0 && a.Amount <= 99
+	case Pieces:
+		return a.Amount >= 0 && a.Amount <= 9
+	}
+	return false
+}
+
+// Encode returns a as the 3 characters of the Free Baggage Allowance item,
+// e.g. 20K. An error is returned if a is not Valid.
+func (a BaggageAllowance) Encode() (string, error) {
+	if !a.Valid() {
+		return "", fmt.Errorf("bcbp: free baggage allowance %d%s cannot be encoded", a.Amount, string(a.Unit))
+	}
+	if a.Unit == Pieces {
+		return fmt.Sprintf("%d%s", a.Amount, string(a.Unit)), nil
+	}
+	return fmt.Sprintf("%02d%s", a.Amount, string(a.Unit)), nil
+}
+
+// String returns a in a human readable format, e.g. 20 kg.
+func (a BaggageAllowance) String() string {
+	return fmt.Sprintf("%d %v", a.Amount, a.Unit)
+}
+
+// Kilograms returns the weight of a in kilograms. false is returned if a is
+// not a weight.
+func (a BaggageAllowance) Kilograms() (float64, bool) {
+	switch a.Unit {
+	case Kilograms:
+		return float64(a.Amount), true
+	case Pounds:
+		return float64(a.Amount) * kilogramsPerPound, true
+	}
+	return 0, false
+}
+
+// Pounds returns the weight of a in pounds. false is returned if a is not a
+// weight.
+func (a BaggageAllowance) Pounds() (float64, bool) {
+	switch a.Unit {
+	case Kilograms:
+		return float64(a.Amount) / kilogramsPerPound, true
+	case Pounds:
+		return float64(a.Amount), true
+	}
+	return 0, false
+}
+
+// BaggageAllowance returns FreeBaggageAllowance parsed using
+// ParseBaggageAllowance. false is returned if l does not have a free baggage
+// allowance or it cannot be parsed.
+func (l Leg) BaggageAllowance() (BaggageAllowance, bool) {
+	a, err := ParseBaggageAllowance(l.FreeBaggageAllowance)
+	return a, err == nil
+}
+
+// SetBaggageAllowance sets FreeBaggageAllowance to a encoded using
+// BaggageAllowance.Encode.
+func (l *Leg) SetBaggageAllowance(a BaggageAllowance) error {
+	s, err := a.Encode()
+	if err != nil {
+		return err
+	}
+	l.FreeBaggageAllowance = s
+	return nil
+}
@@ -0,0 +1,121 @@
+package bcbp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseBaggageAllowance(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    BaggageAllowance
+		wantErr bool
+	}{
+		{s: "20K", want: BaggageAllowance{Amount: 20, Unit: Kilograms}},
+		{s: "40l", want: BaggageAllowance{Amount: 40, Unit: Pounds}},
+		{s: "3PC", want: BaggageAllowance{Amount: 3, Unit: Pieces}},
+		{s: "2pc", want: BaggageAllowance{Amount: 2, Unit: Pieces}},
+		{s: "   ", wantErr: true},
+		{s: "20", wantErr: true},
+		{s: "2K", wantErr: true},
+		{s: "A0K", wantErr: true},
+		{s: "XPC", wantErr: true},
+		{s: "20G", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseBaggageAllowance(tt.s)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseBaggageAllowance() = %v: expected error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBaggageAllowance() returned unexpected error: %+v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBaggageAllowance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaggageAllowance_Encode(t *testing.T) {
+	tests := []struct {
+		a       BaggageAllowance
+		want    string
+		wantErr bool
+	}{
+		{a: BaggageAllowance{Amount: 20, Unit: Kilograms}, want: "20K"},
+		{a: BaggageAllowance{Amount: 5, Unit: Pounds}, want: "05L"},
+		{a: BaggageAllowance{Amount: 3, Unit: Pieces}, want: "3PC"},
+		{a: BaggageAllowance{Amount: 100, Unit: Kilograms}, wantErr: true},
+		{a: BaggageAllowance{Amount: 10, Unit: Pieces}, wantErr: true},
+		{a: BaggageAllowance{Amount: -1, Unit: Pounds}, wantErr: true},
+		{a: BaggageAllowance{Amount: 1, Unit: "G"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a.String(), func(t *testing.T) {
+			got, err := tt.a.Encode()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Encode() = %q: expected error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Encode() returned unexpected error: %+v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Encode() = %q, want %q", got, tt.want)
+			}
+			if !freeBaggageAllowanceValidator.match(got) {
+				t.Errorf("Encode() = %q does not match the format of the Free Baggage Allowance item", got)
+			}
+		})
+	}
+}
+
+func TestBaggageAllowance_Conversion(t *testing.T) {
+	kg, ok := BaggageAllowance{Amount: 50, Unit: Pounds}.Kilograms()
+	if !ok || math.Abs(kg-22.68) > 0.01 {
+		t.Errorf("Kilograms() = %v, %t, want 22.68, true", kg, ok)
+	}
+
+	lb, ok := BaggageAllowance{Amount: 23, Unit: Kilograms}.Pounds()
+	if !ok || math.Abs(lb-50.71) > 0.01 {
+		t.Errorf("Pounds() = %v, %t, want 50.71, true", lb, ok)
+	}
+
+	if _, ok := (BaggageAllowance{Amount: 2, Unit: Pieces}).Kilograms(); ok {
+		t.Error("Kilograms() = true for pieces, want false")
+	}
+	if _, ok := (BaggageAllowance{Amount: 2, Unit: Pieces}).Pounds(); ok {
+		t.Error("Pounds() = true for pieces, want false")
+	}
+}
+
+func TestLeg_BaggageAllowance(t *testing.T) {
+	var l Leg
+	if _, ok := l.BaggageAllowance(); ok {
+		t.Error("BaggageAllowance() = true for empty allowance, want false")
+	}
+
+	want := BaggageAllowance{Amount: 2, Unit: Pieces}
+	if err := l.SetBaggageAllowance(want); err != nil {
+		t.Fatalf("SetBaggageAllowance() returned unexpected error: %+v", err)
+	}
+	if l.FreeBaggageAllowance != "2PC" {
+		t.Errorf("FreeBaggageAllowance = %q, want %q", l.FreeBaggageAllowance, "2PC")
+	}
+	if got, ok := l.BaggageAllowance(); !ok || got != want {
+		t.Errorf("BaggageAllowance() = %v, %t, want %v, true", got, ok, want)
+	}
+
+	if err := l.SetBaggageAllowance(BaggageAllowance{Amount: 12, Unit: Pieces}); err == nil {
+		t.Error("SetBaggageAllowance() = nil: expected error")
+	}
+}
@@ -0,0 +1,150 @@
+package bcbp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BagTagType is the type of a bag tag.
+type BagTagType string
+
+const (
+	BagTagTypeInterline     BagTagType = "0"
+	BagTagTypeFallBack      BagTagType = "1"
+	BagTagTypeInterlineRush BagTagType = "2"
+)
+
+// String returns the description of the bag tag type.
+func (t BagTagType) String() string {
+	switch t {
+	case BagTagTypeInterline:
+		return "Interline tag"
+	case BagTagTypeFallBack:
+		return "Fall-back tag"
+	case BagTagTypeInterlineRush:
+		return "Interline rush tag"
+	}
+	return unknownCode(string(t))
+}
+
+const (
+	// bagTagRangeLen is the length of a Baggage Tag License Plate Number
+	// item.
+	bagTagRangeLen = 13
+
+	// maxBagTagSerial is the highest serial number of a bag tag.
+	maxBagTagSerial = 999999
+
+	// maxBagTagCount is the highest number of consecutive bag tags.
+	maxBagTagCount = 999
+)
+
+// BagTagRange is a series of consecutive bag tags as encoded in the Baggage
+// Tag License Plate Number items, e.g. 0014123456002.
+type BagTagRange struct {
+	// Type is the type of the bag tags.
+	Type BagTagType
+
+	// CarrierCode is the 3 digit numeric code of the carrier that issued the
+	// bag tags.
+	CarrierCode string
+
+	// Serial is the serial number of the first bag tag.
+	Serial int
+
+	// Count is the number of consecutive bag tags, up to 999.
+	Count int
+}
+
+// ParseBagTagRange parses s as a Baggage Tag License Plate Number item.
+func ParseBagTagRange(s string) (BagTagRange, error) {
+	if len(s) != bagTagRangeLen {
+		return BagTagRange{}, fmt.Errorf("bcbp: bag tag license plate number %q must be %d digits", s, bagTagRangeLen)
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return BagTagRange{}, fmt.Errorf("bcbp: bag tag license plate number %q must be %d digits", s, bagTagRangeLen)
+		}
+	}
+	if s[0] > '2' {
+		return BagTagRange{}, fmt.Errorf("bcbp: bag tag license plate number %q has unknown type %q", s, s[0])
+	}
+
+	// s only holds digits, no need to check errors.
+	serial, _ := strconv.Atoi(s[4:10])
+	count, _ := strconv.Atoi(s[10:])
+	return BagTagRange{
+		Type:        BagTagType(s[:1]),
+		CarrierCode: s[1:4],
+		Serial:      serial,
+		Count:       count,
+	}, nil
+}
+
+// String returns r as it is encoded in a Baggage Tag License Plate Number
+// item.
+func (r BagTagRange) String() string {
+	return fmt.Sprintf("%s%s%06d%03d", string(r.Type), r.CarrierCode, r.Serial, r.Count)
+}
+
+// LicensePlates returns the 10 digit license plate of every bag tag in r.
+// That is, the type, the carrier code, and the serial number of the bag tag.
+//
+// nil is returned if Count is negative or greater than 999, or if the serial
+// number of any bag tag in r does not fit in 6 digits.
+func (r BagTagRange) LicensePlates() []string {
+	if r.Count < 0 || r.Count > maxBagTagCount ||
+		r.Serial < 0 || r.Serial > maxBagTagSerial-r.Count+1 {
+		return nil
+	}
+
+	plates := make([]string, 0, r.Count)
+	for i := 0; i < r.Count; i++ {
+		plates = append(plates, fmt.Sprintf("%s%s%06d", string(r.Type), r.CarrierCode, r.Serial+i))
+	}
+	return plates
+}
+
+// Contains reports whether the 10 digit license plate is one of the bag tags
+// in r.
+func (r BagTagRange) Contains(plate string) bool {
+	for _, p := range r.LicensePlates() {
+		if p == plate {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCarrier returns an error if the carrier code of r does not match
+// the AirlineNumericCode of l.
+func (r BagTagRange) ValidateCarrier(l Leg) error {
+	if r.CarrierCode != strings.TrimSpace(l.AirlineNumericCode) {
+		return fmt.Errorf("bcbp: bag tag carrier code %q does not match airline numeric code %q", r.CarrierCode, l.AirlineNumericCode)
+	}
+	return nil
+}
+
+// BagTagRanges returns the bag tag ranges encoded in
+// BaggageTagLicensePlateNumber,
+// FirstNonConsecutiveBaggageTagLicensePlateNumber, and
+// SecondNonConsecutiveBaggageTagLicensePlateNumber. Empty items are skipped.
+func (b BCBP) BagTagRanges() ([]BagTagRange, error) {
+	var ranges []BagTagRange
+	for _, s := range []string{
+		b.BaggageTagLicensePlateNumber,
+		b.FirstNonConsecutiveBaggageTagLicensePlateNumber,
+		b.SecondNonConsecutiveBaggageTagLicensePlateNumber,
+	} {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		r, err := ParseBagTagRange(s)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
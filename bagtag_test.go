@@ -0,0 +1,147 @@
+package bcbp
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseBagTagRange(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    BagTagRange
+		wantErr bool
+	}{
+		{
+			s:    "0014123456002",
+			want: BagTagRange{Type: BagTagTypeInterline, CarrierCode: "014", Serial: 123456, Count: 2},
+		},
+		{
+			s:    "2220000001999",
+			want: BagTagRange{Type: BagTagTypeInterlineRush, CarrierCode: "220", Serial: 1, Count: 999},
+		},
+		{s: "3014123456002", wantErr: true},
+		{s: "001412345600", wantErr: true},
+		{s: "001412345600A", wantErr: true},
+		{s: "             ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseBagTagRange(tt.s)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseBagTagRange() = %v: expected error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBagTagRange() returned unexpected error: %+v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBagTagRange() = %+v, want %+v", got, tt.want)
+			}
+			if got.String() != tt.s {
+				t.Errorf("String() = %q, want %q", got.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestBagTagRange_LicensePlates(t *testing.T) {
+	tests := []struct {
+		name string
+		r    BagTagRange
+		want []string
+	}{
+		{
+			name: "consecutive",
+			r:    BagTagRange{Type: BagTagTypeFallBack, CarrierCode: "014", Serial: 999997, Count: 3},
+			want: []string{"1014999997", "1014999998", "1014999999"},
+		},
+		{
+			name: "no bag tags",
+			r:    BagTagRange{Type: BagTagTypeFallBack, CarrierCode: "014", Serial: 123456},
+			want: []string{},
+		},
+		{
+			name: "negative count",
+			r:    BagTagRange{Type: BagTagTypeFallBack, CarrierCode: "014", Serial: 123456, Count: -1},
+		},
+		{
+			name: "count too large",
+			r:    BagTagRange{Type: BagTagTypeFallBack, CarrierCode: "014", Serial: 0, Count: 1000},
+		},
+		{
+			name: "negative serial",
+			r:    BagTagRange{Type: BagTagTypeFallBack, CarrierCode: "014", Serial: -1, Count: 2},
+		},
+		{
+			name: "serial overflows 6 digits",
+			r:    BagTagRange{Type: BagTagTypeFallBack, CarrierCode: "014", Serial: 999998, Count: 3},
+		},
+		{
+			name: "first serial overflows 6 digits",
+			r:    BagTagRange{Type: BagTagTypeFallBack, CarrierCode: "014", Serial: 1000000, Count: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.r.LicensePlates()); diff != "" {
+				t.Errorf("LicensePlates() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBagTagRange_Contains(t *testing.T) {
+	r := BagTagRange{Type: BagTagTypeFallBack, CarrierCode: "014", Serial: 999997, Count: 3}
+	if !r.Contains("1014999999") {
+		t.Error("Contains() = false, want true")
+	}
+	if r.Contains("1014000000") {
+		t.Error("Contains() = true, want false")
+	}
+
+	r.Count = -1
+	if r.Contains("1014999997") {
+		t.Error("Contains() = true, want false")
+	}
+}
+
+func TestBCBP_BagTagRanges(t *testing.T) {
+	data, err := os.ReadFile("testdata/full_single.input")
+	if err != nil {
+		t.Fatalf("failed reading .input file: %v", err)
+	}
+	b, err := FromStr(string(data))
+	if err != nil {
+		t.Fatalf("FromStr() returned unexpected error: %+v", err)
+	}
+
+	got, err := b.BagTagRanges()
+	if err != nil {
+		t.Fatalf("BagTagRanges() returned unexpected error: %+v", err)
+	}
+	want := []BagTagRange{
+		{Type: BagTagTypeInterline, CarrierCode: "014", Serial: 123456, Count: 2},
+		{Type: BagTagTypeInterline, CarrierCode: "014", Serial: 123467, Count: 1},
+		{Type: BagTagTypeInterline, CarrierCode: "014", Serial: 123478, Count: 901},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("BagTagRanges() mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, r := range got {
+		if err := r.ValidateCarrier(b.Legs[0]); err != nil {
+			t.Errorf("ValidateCarrier() returned unexpected error: %+v", err)
+		}
+	}
+
+	r := BagTagRange{Type: BagTagTypeInterline, CarrierCode: "220", Serial: 1, Count: 1}
+	if err := r.ValidateCarrier(b.Legs[0]); err == nil {
+		t.Error("ValidateCarrier() = nil: expected error")
+	}
+}
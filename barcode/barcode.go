@@ -0,0 +1,126 @@
+// Package barcode reads IATA 792 Bar Coded Boarding Passes from barcode
+// images and renders them as barcode images.
+//
+// The symbologies permitted by IATA 792 are supported: PDF417, Aztec, QR
+// Code, and Data Matrix.
+package barcode
+
+import (
+	"errors"
+	"image"
+
+	"github.com/jandauz/boarding-pass"
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/aztec"
+	"github.com/makiuchi-d/gozxing/datamatrix"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// Symbology is a two-dimensional barcode symbology permitted by IATA 792.
+type Symbology int
+
+const (
+	// PDF417 is the symbology used by printed boarding passes.
+	PDF417 Symbology = iota + 1
+
+	// Aztec is a symbology used by mobile boarding passes.
+	Aztec
+
+	// QRCode is a symbology used by mobile boarding passes.
+	QRCode
+
+	// DataMatrix is a symbology used by mobile boarding passes.
+	DataMatrix
+)
+
+// String returns the name of the symbology.
+func (s Symbology) String() string {
+	switch s {
+	case PDF417:
+		return "PDF417"
+	case Aztec:
+		return "Aztec"
+	case QRCode:
+		return "QR Code"
+	case DataMatrix:
+		return "Data Matrix"
+	}
+	return "Unknown"
+}
+
+// ErrNotFound is returned when no barcode could be found in an image.
+var ErrNotFound = errors.New("barcode: no barcode found in image")
+
+// reader reads a barcode of a symbology from the luminance of an image.
+type reader struct {
+	symbology Symbology
+	read      func(src gozxing.LuminanceSource) (string, error)
+}
+
+// readers are tried in order. PDF417 is tried first since it is the
+// symbology used by most boarding passes.
+var readers = []reader{
+	{symbology: PDF417, read: readPDF417},
+	{symbology: Aztec, read: zxingReader(aztec.NewAztecReader())},
+	{symbology: QRCode, read: zxingReader(qrcode.NewQRCodeReader())},
+	{symbology: DataMatrix, read: zxingReader(datamatrix.NewDataMatrixReader())},
+}
+
+// zxingReader returns a function that reads a barcode using r.
+func zxingReader(r gozxing.Reader) func(src gozxing.LuminanceSource) (string, error) {
+	hints := map[gozxing.DecodeHintType]interface{}{
+		gozxing.DecodeHintType_TRY_HARDER: true,
+	}
+	return func(src gozxing.LuminanceSource) (string, error) {
+		bmp, err := gozxing.NewBinaryBitmap(gozxing.NewHybridBinarizer(src))
+		if err != nil {
+			return "", err
+		}
+		res, err := r.Decode(bmp, hints)
+		if err != nil {
+			return "", err
+		}
+		return res.GetText(), nil
+	}
+}
+
+// Read locates a barcode in img and returns its symbology and the data it
+// encodes. ErrNotFound is returned if no barcode could be found.
+//
+// If a PDF417 barcode is found but cannot be read, e.g. it is too damaged to
+// be corrected, the error reading it is returned instead of ErrNotFound.
+func Read(img image.Image) (Symbology, string, error) {
+	src := gozxing.NewLuminanceSourceFromImage(img)
+
+	var pdf417Err error
+	for _, r := range readers {
+		s, err := r.read(src)
+		if err == nil {
+			return r.symbology, s, nil
+		}
+		if r.symbology == PDF417 && err != errPDF417NotFound {
+			pdf417Err = err
+		}
+	}
+
+	if pdf417Err != nil {
+		return PDF417, "", pdf417Err
+	}
+	return 0, "", ErrNotFound
+}
+
+// Decode locates a barcode in img and decodes the data it encodes into a
+// BCBP using bcbp.FromStrWithOptions with opts. The symbology of the barcode
+// is returned along with the BCBP.
+//
+// ErrNotFound is returned if no barcode could be found. Otherwise, errors
+// decoding the data are returned as is.
+func Decode(img image.Image, opts ...bcbp.Option) (Symbology, bcbp.BCBP, error) {
+	sym, s, err := Read(img)
+	if err != nil {
+		return sym, bcbp.BCBP{}, err
+	}
+
+	b, err := bcbp.FromStrWithOptions(s, opts...)
+	return sym, b, err
+}
@@ -0,0 +1,408 @@
+package barcode
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bc "github.com/boombuler/barcode"
+	bcaztec "github.com/boombuler/barcode/aztec"
+	bcdatamatrix "github.com/boombuler/barcode/datamatrix"
+	bcpdf417 "github.com/boombuler/barcode/pdf417"
+	bcqr "github.com/boombuler/barcode/qr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jandauz/boarding-pass"
+	"github.com/makiuchi-d/gozxing"
+)
+
+const mandatory = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100"
+
+// update is a flag to regenerate the .jpg capture files. It should be used
+// any time a capture is added or the way captures are simulated changes.
+var update = flag.Bool("update", false, "update capture fixtures") //nolint
+
+// render renders bc scaled by scale with a quiet zone of 4 modules.
+func render(t *testing.T, code bc.Barcode, scaleX, scaleY int) image.Image {
+	t.Helper()
+
+	b := code.Bounds()
+	scaled, err := bc.Scale(code, b.Dx()*scaleX, b.Dy()*scaleY)
+	if err != nil {
+		t.Fatalf("Scale() returned unexpected error: %+v", err)
+	}
+
+	quiet := 4 * scaleX
+	img := image.NewGray(image.Rect(0, 0, b.Dx()*scaleX+2*quiet, b.Dy()*scaleY+2*quiet))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(img, scaled.Bounds().Add(image.Pt(quiet, quiet)), scaled, image.Point{}, draw.Src)
+	return img
+}
+
+// marshal returns the JSON encoding of b.
+func marshal(t *testing.T, b bcbp.BCBP) string {
+	t.Helper()
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned unexpected error: %+v", err)
+	}
+	return string(data)
+}
+
+// rotate rotates img clockwise by 90 degrees.
+func rotate(img image.Image) image.Image {
+	b := img.Bounds()
+	rotated := image.NewGray(image.Rect(0, 0, b.Dy(), b.Dx()))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			rotated.Set(b.Dy()-1-y, x, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return rotated
+}
+
+// capture describes a simulated scan or photo of a barcode.
+type capture struct {
+	// scale is the number of pixels of the capture per pixel of the
+	// rendered barcode.
+	scale float64
+
+	// angle is the clockwise rotation of the barcode in degrees.
+	angle float64
+
+	// blur is the radius, in pixels, of the box blur of the lens.
+	blur int
+
+	// noise is the standard deviation of the noise of the sensor.
+	noise float64
+
+	// shade is how much darker the right edge is than the left edge, e.g.
+	// due to a shadow or uneven lighting.
+	shade float64
+
+	// stain is the bounding box of an elliptical stain on the rendered
+	// barcode.
+	stain image.Rectangle
+
+	// quality is the quality of the JPEG encoding of the capture.
+	quality int
+}
+
+// jpeg returns the JPEG encoding of a capture of the PDF417 barcode of s.
+func (c capture) jpeg(t *testing.T, s string) []byte {
+	t.Helper()
+
+	sym, err := RenderString(s, PDF417)
+	if err != nil {
+		t.Fatalf("RenderString() returned unexpected error: %+v", err)
+	}
+
+	// The ink is not quite black and the paper not quite white.
+	b := sym.Bounds()
+	src := make([]float64, b.Dx()*b.Dy())
+	stained := func(x, y int) bool {
+		if c.stain.Empty() {
+			return false
+		}
+		rx, ry := float64(c.stain.Dx())/2, float64(c.stain.Dy())/2
+		dx := (float64(x-c.stain.Min.X) - rx) / rx
+		dy := (float64(y-c.stain.Min.Y) - ry) / ry
+		return dx*dx+dy*dy <= 1
+	}
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			switch {
+			case stained(x, y):
+				src[y*b.Dx()+x] = 120
+			case sym.At(b.Min.X+x, b.Min.Y+y) == color.Gray{Y: 0}:
+				src[y*b.Dx()+x] = 40
+			default:
+				src[y*b.Dx()+x] = 220
+			}
+		}
+	}
+	at := func(x, y int) float64 {
+		if x < 0 || y < 0 || x >= b.Dx() || y >= b.Dy() {
+			return 220
+		}
+		return src[y*b.Dx()+x]
+	}
+
+	// Scale and rotate the barcode around its center with bilinear
+	// interpolation, leaving a margin of paper around it.
+	rad := c.angle * math.Pi / 180
+	sin, cos := math.Sin(rad), math.Cos(rad)
+	w := float64(b.Dx()) * c.scale
+	h := float64(b.Dy()) * c.scale
+	width := int(math.Abs(w*cos)+math.Abs(h*sin)) + 40
+	height := int(math.Abs(w*sin)+math.Abs(h*cos)) + 40
+	dst := make([]float64, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			dx, dy := float64(x)-float64(width)/2, float64(y)-float64(height)/2
+			sx := (cos*dx+sin*dy)/c.scale + float64(b.Dx())/2
+			sy := (cos*dy-sin*dx)/c.scale + float64(b.Dy())/2
+			x0, y0 := int(math.Floor(sx)), int(math.Floor(sy))
+			fx, fy := sx-float64(x0), sy-float64(y0)
+			dst[y*width+x] = at(x0, y0)*(1-fx)*(1-fy) + at(x0+1, y0)*fx*(1-fy) +
+				at(x0, y0+1)*(1-fx)*fy + at(x0+1, y0+1)*fx*fy
+		}
+	}
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	rnd := rand.New(rand.NewSource(1))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var sum, n float64
+			for j := y - c.blur; j <= y+c.blur; j++ {
+				for i := x - c.blur; i <= x+c.blur; i++ {
+					if i >= 0 && j >= 0 && i < width && j < height {
+						sum += dst[j*width+i]
+						n++
+					}
+				}
+			}
+			v := sum / n * (1 - c.shade*float64(x)/float64(width))
+			v += rnd.NormFloat64() * c.noise
+			img.SetGray(x, y, color.Gray{Y: uint8(math.Max(0, math.Min(255, v)))})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: c.quality}); err != nil {
+		t.Fatalf("jpeg.Encode() returned unexpected error: %+v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	data, err := os.ReadFile("../testdata/full_multi.input")
+	if err != nil {
+		t.Fatalf("failed reading .input file: %v", err)
+	}
+	full := string(data)
+
+	tests := []struct {
+		name      string
+		data      string
+		encode    func(s string) (bc.Barcode, error)
+		symbology Symbology
+		rotations int
+	}{
+		{
+			name:      "pdf417",
+			data:      full,
+			encode:    func(s string) (bc.Barcode, error) { return bcpdf417.Encode(s, 2) },
+			symbology: PDF417,
+		},
+		{
+			name:      "pdf417 rotated",
+			data:      mandatory,
+			encode:    func(s string) (bc.Barcode, error) { return bcpdf417.Encode(s, 2) },
+			symbology: PDF417,
+			rotations: 1,
+		},
+		{
+			name:      "pdf417 upside down",
+			data:      mandatory,
+			encode:    func(s string) (bc.Barcode, error) { return bcpdf417.Encode(s, 2) },
+			symbology: PDF417,
+			rotations: 2,
+		},
+		{
+			name:      "aztec",
+			data:      full,
+			encode:    func(s string) (bc.Barcode, error) { return bcaztec.Encode([]byte(s), 23, 0) },
+			symbology: Aztec,
+		},
+		{
+			name:      "qr code",
+			data:      full,
+			encode:    func(s string) (bc.Barcode, error) { return bcqr.Encode(s, bcqr.M, bcqr.Auto) },
+			symbology: QRCode,
+		},
+		{
+			name:      "data matrix",
+			data:      mandatory,
+			encode:    bcdatamatrix.Encode,
+			symbology: DataMatrix,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := tt.encode(tt.data)
+			if err != nil {
+				t.Fatalf("Encode() returned unexpected error: %+v", err)
+			}
+
+			scaleY := 4
+			if tt.symbology == PDF417 {
+				scaleY = 3
+			}
+			img := render(t, code, 4, scaleY)
+			for i := 0; i < tt.rotations; i++ {
+				img = rotate(img)
+			}
+
+			sym, b, err := Decode(img)
+			if err != nil {
+				t.Fatalf("Decode() returned unexpected error: %+v", err)
+			}
+			if sym != tt.symbology {
+				t.Errorf("Decode() symbology = %v, want %v", sym, tt.symbology)
+			}
+
+			want, err := bcbp.FromStr(tt.data)
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", tt.data, err)
+			}
+			if diff := cmp.Diff(marshal(t, want), marshal(t, b)); diff != "" {
+				t.Errorf("Decode() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRead_Captures(t *testing.T) {
+	data, err := os.ReadFile("../testdata/full_multi.input")
+	if err != nil {
+		t.Fatalf("failed reading .input file: %v", err)
+	}
+	full := string(data)
+
+	tests := []struct {
+		name    string
+		data    string
+		capture capture
+	}{
+		{
+			name:    "scan",
+			data:    full,
+			capture: capture{scale: 1.37, angle: 0.8, blur: 1, noise: 6, shade: 0.1, quality: 85},
+		},
+		{
+			name:    "photo",
+			data:    full,
+			capture: capture{scale: 1.6, angle: -3.5, blur: 1, noise: 10, shade: 0.35, quality: 70},
+		},
+		{
+			name:    "photo_rotated",
+			data:    mandatory,
+			capture: capture{scale: 2.2, angle: 92, blur: 1, noise: 10, shade: 0.3, quality: 60},
+		},
+		{
+			name:    "photo_upside_down",
+			data:    mandatory,
+			capture: capture{scale: 1.8, angle: 178.5, blur: 1, noise: 10, shade: 0.3, quality: 60},
+		},
+		{
+			name:    "stained",
+			data:    full,
+			capture: capture{scale: 1.5, angle: 1, blur: 1, noise: 8, shade: 0.2, stain: image.Rect(100, 20, 300, 90), quality: 75},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join("testdata", tt.name+".jpg")
+			if *update {
+				t.Logf("update %s capture", path)
+				if err := os.WriteFile(path, tt.capture.jpeg(t, tt.data), 0644); err != nil {
+					t.Fatalf("failed to update capture: %v", err)
+				}
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatalf("failed reading capture: %v", err)
+			}
+			defer f.Close()
+			img, err := jpeg.Decode(f)
+			if err != nil {
+				t.Fatalf("jpeg.Decode() returned unexpected error: %+v", err)
+			}
+
+			sym, got, err := Read(img)
+			if err != nil {
+				t.Fatalf("Read() returned unexpected error: %+v", err)
+			}
+			if sym != PDF417 {
+				t.Errorf("Read() symbology = %v, want %v", sym, PDF417)
+			}
+			if diff := cmp.Diff(tt.data, got); diff != "" {
+				t.Errorf("Read() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecode_NotFound(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 200, 200))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	if _, _, err := Decode(img); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Decode() = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestCorrectPDF417(t *testing.T) {
+	code, err := bcpdf417.Encode(mandatory, 2)
+	if err != nil {
+		t.Fatalf("Encode() returned unexpected error: %+v", err)
+	}
+
+	bmp, err := gozxing.NewBinaryBitmapFromImage(render(t, code, 2, 3))
+	if err != nil {
+		t.Fatalf("NewBinaryBitmapFromImage() returned unexpected error: %+v", err)
+	}
+	m, err := bmp.GetBlackMatrix()
+	if err != nil {
+		t.Fatalf("GetBlackMatrix() returned unexpected error: %+v", err)
+	}
+
+	v := bitView{m: m}
+	var rows []pdf417Row
+	for y := 0; y < v.height(); y++ {
+		if row, ok := scanPDF417Row(v.runs(y)); ok {
+			rows = append(rows, row)
+		}
+	}
+
+	want, numEC, err := assemblePDF417(rows)
+	if err != nil {
+		t.Fatalf("assemblePDF417() returned unexpected error: %+v", err)
+	}
+
+	// Security level 2 uses 8 error correction codewords which can correct
+	// up to 4 errors.
+	got := append([]int(nil), want...)
+	for _, i := range []int{0, 3, len(got) / 2, len(got) - 1} {
+		got[i] = (got[i] + 1) % 929
+	}
+
+	if err := correctPDF417(got, numEC); err != nil {
+		t.Fatalf("correctPDF417() returned unexpected error: %+v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("codewords mismatch (-want +got):\n%s", diff)
+	}
+
+	// Too many errors cannot be corrected.
+	for i := 0; i < numEC; i++ {
+		got[i] = (got[i] + 1) % 929
+	}
+	if err := correctPDF417(got, numEC); err == nil {
+		t.Error("correctPDF417() = nil: expected error")
+	}
+}
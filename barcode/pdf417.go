@@ -0,0 +1,641 @@
+package barcode
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/makiuchi-d/gozxing"
+)
+
+var (
+	// errPDF417NotFound is returned when no PDF417 row could be read.
+	errPDF417NotFound = errors.New("barcode: no PDF417 barcode found")
+
+	// errPDF417Format is returned when the codewords of a PDF417 barcode do
+	// not form a valid PDF417 symbol.
+	errPDF417Format = errors.New("barcode: malformed PDF417 barcode")
+
+	// errPDF417Checksum is returned when the codewords of a PDF417 barcode
+	// have more errors than can be corrected.
+	errPDF417Checksum = errors.New("barcode: PDF417 barcode has too many errors to be corrected")
+
+	// errPDF417Unsupported is returned when a PDF417 barcode uses a feature,
+	// such as Macro PDF417, that is not supported.
+	errPDF417Unsupported = errors.New("barcode: PDF417 barcode uses an unsupported feature")
+)
+
+var (
+	// pdf417StartPattern is the width, in modules, of each bar and space of
+	// the start pattern.
+	pdf417StartPattern = []int{8, 1, 1, 1, 1, 1, 1, 3}
+
+	// pdf417StopPattern is the width, in modules, of each bar and space of
+	// the stop pattern.
+	pdf417StopPattern = []int{7, 1, 1, 3, 1, 1, 1, 2, 1}
+)
+
+// pdf417Codeword is the value and cluster of a codeword pattern.
+type pdf417Codeword struct {
+	value   int
+	cluster int
+}
+
+// pdf417Codewords maps the pattern of every codeword to its value and cluster.
+var pdf417Codewords = func() map[int]pdf417Codeword {
+	m := make(map[int]pdf417Codeword, len(pdf417Patterns)*len(pdf417Patterns[0]))
+	for cluster, patterns := range pdf417Patterns {
+		for value, pattern := range patterns {
+			m[pattern] = pdf417Codeword{value: value, cluster: cluster}
+		}
+	}
+	return m
+}()
+
+// pdf417Row is a row of a PDF417 barcode read from a single scan line.
+type pdf417Row struct {
+	// number is the index of the row in the barcode.
+	number int
+
+	// cluster is the cluster of the codewords of the row.
+	cluster int
+
+	// codewords are the codewords of the row including the left and right
+	// row indicators. Codewords that could not be read are -1.
+	codewords []int
+}
+
+// pdf417Skews are the slopes of the scan lines tried, in order, when
+// reading a PDF417 barcode. Scan lines of a barcode that is not level, e.g.
+// in a photo, must follow its rows to read them. Slopes up to about 5 degrees
+// are tried in steps small enough for a scan line to stay within a row.
+var pdf417Skews = func() []float64 {
+	skews := []float64{0}
+	for i := 1; i <= 18; i++ {
+		skews = append(skews, float64(i)*0.005, -float64(i)*0.005)
+	}
+	return skews
+}()
+
+// readPDF417 reads a PDF417 barcode from src. The barcode can be rotated by
+// any multiple of 90 degrees, give or take about 5 degrees.
+//
+// The image is binarized with a local threshold first. The wide bars of the
+// start and stop patterns of a noisy photo can come out speckled that way, so
+// a global threshold is tried next.
+func readPDF417(src gozxing.LuminanceSource) (string, error) {
+	err := errPDF417NotFound
+	for _, binarizer := range []gozxing.Binarizer{
+		gozxing.NewHybridBinarizer(src),
+		gozxing.NewGlobalHistgramBinarizer(src),
+	} {
+		m, bErr := binarizer.GetBlackMatrix()
+		if bErr != nil {
+			return "", bErr
+		}
+
+		s, mErr := readPDF417Matrix(m)
+		if mErr == nil {
+			return s, nil
+		}
+		if mErr != errPDF417NotFound {
+			err = mErr
+		}
+	}
+	return "", err
+}
+
+// readPDF417Matrix reads a PDF417 barcode from the binarized image m.
+func readPDF417Matrix(m *gozxing.BitMatrix) (string, error) {
+	err := errPDF417NotFound
+	for _, skew := range pdf417Skews {
+		for rotation := 0; rotation < 4; rotation++ {
+			v := bitView{m: m, rotation: rotation, skew: skew}
+			var rows []pdf417Row
+			margin := int(math.Ceil(math.Abs(skew) * float64(v.width())))
+			for y := -margin; y < v.height()+margin; y++ {
+				if row, ok := scanPDF417Row(v.runs(y)); ok {
+					rows = append(rows, row)
+				}
+			}
+			if len(rows) == 0 {
+				continue
+			}
+
+			s, rowsErr := decodePDF417Rows(rows)
+			if rowsErr == nil {
+				return s, nil
+			}
+			err = rowsErr
+		}
+	}
+	return "", err
+}
+
+// bitView is a view of m rotated clockwise by rotation multiples of 90
+// degrees whose rows slope down by skew pixels per pixel.
+type bitView struct {
+	m        *gozxing.BitMatrix
+	rotation int
+	skew     float64
+}
+
+func (v bitView) width() int {
+	if v.rotation%2 == 0 {
+		return v.m.GetWidth()
+	}
+	return v.m.GetHeight()
+}
+
+func (v bitView) height() int {
+	if v.rotation%2 == 0 {
+		return v.m.GetHeight()
+	}
+	return v.m.GetWidth()
+}
+
+// get reports whether pixel (x, y) is black. Pixels outside of m are white.
+func (v bitView) get(x, y int) bool {
+	y += int(math.Round(v.skew * float64(x)))
+	if x < 0 || y < 0 || x >= v.width() || y >= v.height() {
+		return false
+	}
+
+	w, h := v.m.GetWidth(), v.m.GetHeight()
+	switch v.rotation {
+	case 1:
+		return v.m.Get(y, h-1-x)
+	case 2:
+		return v.m.Get(w-1-x, h-1-y)
+	case 3:
+		return v.m.Get(w-1-y, x)
+	}
+	return v.m.Get(x, y)
+}
+
+// runs returns the length of each run of white and black pixels of row y.
+// The first run is always white, even if it is empty, so that black runs are
+// at odd indexes.
+func (v bitView) runs(y int) []int {
+	runs := []int{0}
+	black := false
+	for x := 0; x < v.width(); x++ {
+		if v.get(x, y) != black {
+			black = !black
+			runs = append(runs, 0)
+		}
+		runs[len(runs)-1]++
+	}
+	return runs
+}
+
+// scanPDF417Row reads the codewords between the start and stop patterns of
+// a scan line made up of runs.
+//
+// A codeword that cannot be read, e.g. because of a stain, does not discard
+// the whole row. The codewords are read forward from the start pattern and
+// backward from the stop pattern, and those in between are -1. The number of
+// codewords in between is worked out from their width.
+func scanPDF417Row(runs []int) (pdf417Row, bool) {
+	for start := 1; start+len(pdf417StartPattern) <= len(runs); start += 2 {
+		if !matchPattern(runs[start:start+len(pdf417StartPattern)], pdf417StartPattern) {
+			continue
+		}
+
+		cluster := -1
+		var left []int
+		i := start + len(pdf417StartPattern)
+		for i+len(pdf417StopPattern) <= len(runs) {
+			if matchPattern(runs[i:], pdf417StopPattern) {
+				break
+			}
+
+			cw, ok := readCodeword(runs[i : i+8])
+			if !ok || (cluster >= 0 && cw.cluster != cluster) {
+				break
+			}
+			cluster = cw.cluster
+			left = append(left, cw.value)
+			i += 8
+		}
+
+		stop := i
+		for stop+len(pdf417StopPattern) <= len(runs) && !matchPattern(runs[stop:], pdf417StopPattern) {
+			stop += 2
+		}
+		if stop+len(pdf417StopPattern) > len(runs) {
+			continue
+		}
+
+		var right []int
+		j := stop
+		for j-8 >= i {
+			cw, ok := readCodeword(runs[j-8 : j])
+			if !ok || (cluster >= 0 && cw.cluster != cluster) {
+				break
+			}
+			cluster = cw.cluster
+			right = append([]int{cw.value}, right...)
+			j -= 8
+		}
+
+		// Each codeword is 17 modules wide, the same as the start pattern.
+		var width, startWidth int
+		for k := start; k < stop; k++ {
+			if k < start+len(pdf417StartPattern) {
+				startWidth += runs[k]
+			} else {
+				width += runs[k]
+			}
+		}
+		n := len(left) + len(right)
+		if j > i {
+			n = int(math.Round(float64(width) / float64(startWidth)))
+		}
+
+		// A row holds at least the left and right row indicators and one
+		// data codeword.
+		if n < 3 || len(left)+len(right) > n {
+			continue
+		}
+		row := pdf417Row{cluster: cluster, codewords: make([]int, n)}
+		for k := range row.codewords {
+			row.codewords[k] = -1
+		}
+		copy(row.codewords, left)
+		copy(row.codewords[n-len(right):], right)
+
+		// Both row indicators encode the row number.
+		l, r := row.codewords[0], row.codewords[n-1]
+		switch {
+		case l >= 0 && r >= 0 && l/30 != r/30:
+			continue
+		case l >= 0:
+			row.number = l/30*3 + cluster
+		case r >= 0:
+			row.number = r/30*3 + cluster
+		default:
+			continue
+		}
+		return row, true
+	}
+	return pdf417Row{}, false
+}
+
+// matchPattern reports whether the width of each run is proportional to the
+// width, in modules, of pattern.
+func matchPattern(runs []int, pattern []int) bool {
+	var total, modules int
+	for i := range pattern {
+		total += runs[i]
+		modules += pattern[i]
+	}
+
+	unit := float64(total) / float64(modules)
+	for i := range pattern {
+		if math.Abs(float64(runs[i])-float64(pattern[i])*unit) > 0.6*unit {
+			return false
+		}
+	}
+	return true
+}
+
+// readCodeword reads the codeword made up of the 4 bars and 4 spaces of runs.
+func readCodeword(runs []int) (pdf417Codeword, bool) {
+	var total int
+	for _, r := range runs {
+		total += r
+	}
+
+	// Round the edge of each bar and space to the nearest module so that
+	// the codeword is always 17 modules wide.
+	unit := float64(total) / 17
+	var pattern, edge, modules int
+	for i, r := range runs {
+		edge += r
+		end := int(math.Round(float64(edge) / unit))
+		width := end - modules
+		if width < 1 || width > 6 {
+			return pdf417Codeword{}, false
+		}
+		modules = end
+
+		pattern <<= width
+		if i%2 == 0 {
+			pattern |= 1<<width - 1
+		}
+	}
+
+	cw, ok := pdf417Codewords[pattern]
+	return cw, ok
+}
+
+// decodePDF417Rows assembles the codewords of rows, corrects errors, and
+// decodes the data codewords.
+func decodePDF417Rows(rows []pdf417Row) (string, error) {
+	codewords, numEC, err := assemblePDF417(rows)
+	if err != nil {
+		return "", err
+	}
+
+	if err := correctPDF417(codewords, numEC); err != nil {
+		return "", err
+	}
+
+	// The first codeword is the symbol length descriptor. It is the number
+	// of data codewords including itself.
+	n := codewords[0]
+	if n == 0 {
+		n = len(codewords) - numEC
+	}
+	if n < 1 || n > len(codewords)-numEC {
+		return "", errPDF417Format
+	}
+	return decodePDF417Codewords(codewords[:n])
+}
+
+// assemblePDF417 assembles the codewords of rows in order. The number of
+// error correction codewords is also returned.
+func assemblePDF417(rows []pdf417Row) ([]int, int, error) {
+	// Scan lines of the same row may be read differently. The most common
+	// number of columns and value of each codeword is used.
+	colVotes := map[int]int{}
+	for _, row := range rows {
+		colVotes[len(row.codewords)-2]++
+	}
+	cols := mostVoted(colVotes)
+
+	var numRows int
+	votes := map[int][]map[int]int{}
+	ecVotes := map[int]int{}
+	for _, row := range rows {
+		if len(row.codewords)-2 != cols {
+			continue
+		}
+		if row.number+1 > numRows {
+			numRows = row.number + 1
+		}
+
+		if votes[row.number] == nil {
+			votes[row.number] = make([]map[int]int, cols)
+			for i := range votes[row.number] {
+				votes[row.number][i] = map[int]int{}
+			}
+		}
+		for i, cw := range row.codewords[1 : cols+1] {
+			if cw >= 0 {
+				votes[row.number][i][cw]++
+			}
+		}
+
+		// The error correction level is encoded in the left row indicator
+		// of cluster 3 and the right row indicator of cluster 6.
+		l, r := row.codewords[0], row.codewords[len(row.codewords)-1]
+		switch {
+		case row.cluster == 1 && l >= 0:
+			ecVotes[l%30/3]++
+		case row.cluster == 2 && r >= 0:
+			ecVotes[r%30/3]++
+		}
+	}
+	if len(ecVotes) == 0 {
+		return nil, 0, errPDF417Format
+	}
+	numEC := 2 << mostVoted(ecVotes)
+
+	// Codewords that could not be read in any scan line are erasures.
+	codewords := make([]int, numRows*cols)
+	var erasures int
+	for r := 0; r < numRows; r++ {
+		for c := 0; c < cols; c++ {
+			if votes[r] == nil || len(votes[r][c]) == 0 {
+				erasures++
+				continue
+			}
+			codewords[r*cols+c] = mostVoted(votes[r][c])
+		}
+	}
+	if erasures > numEC/2 || numEC >= len(codewords) {
+		return nil, 0, errPDF417Checksum
+	}
+	return codewords, numEC, nil
+}
+
+// mostVoted returns the key with the most votes. Ties are broken by the
+// smallest key so that the result is deterministic.
+func mostVoted(votes map[int]int) int {
+	best, bestVotes := 0, -1
+	for k, v := range votes {
+		if v > bestVotes || (v == bestVotes && k < best) {
+			best, bestVotes = k, v
+		}
+	}
+	return best
+}
+
+const (
+	pdf417TextLatch    = 900
+	pdf417ByteLatch    = 901
+	pdf417NumericLatch = 902
+	pdf417ByteShift    = 913
+	pdf417ByteLatch6   = 924
+)
+
+// decodePDF417Codewords decodes the data codewords of a PDF417 barcode.
+// The first codeword is the symbol length descriptor.
+func decodePDF417Codewords(codewords []int) (string, error) {
+	var sb strings.Builder
+	i := 1
+	for i < len(codewords) {
+		cw := codewords[i]
+		switch {
+		case cw < pdf417TextLatch:
+			// Text compaction is the default mode.
+			i = textCompaction(codewords, i, &sb)
+		case cw == pdf417TextLatch:
+			i = textCompaction(codewords, i+1, &sb)
+		case cw == pdf417ByteLatch, cw == pdf417ByteLatch6:
+			i = byteCompaction(cw, codewords, i+1, &sb)
+		case cw == pdf417NumericLatch:
+			var err error
+			i, err = numericCompaction(codewords, i+1, &sb)
+			if err != nil {
+				return "", err
+			}
+		case cw == pdf417ByteShift:
+			if i+1 >= len(codewords) {
+				return "", errPDF417Format
+			}
+			sb.WriteByte(byte(codewords[i+1]))
+			i += 2
+		default:
+			// Macro PDF417 and ECIs are not used by boarding passes.
+			return "", errPDF417Unsupported
+		}
+	}
+	return sb.String(), nil
+}
+
+// textSubMode is a sub-mode of the text compaction mode.
+type textSubMode int
+
+const (
+	textAlpha textSubMode = iota
+	textLower
+	textMixed
+	textPunct
+	textAlphaShift
+	textPunctShift
+)
+
+var (
+	// textMixedChars are the characters of the mixed sub-mode.
+	textMixedChars = "0123456789&\r\t,:#-.$/+%*=^"
+
+	// textPunctChars are the characters of the punctuation sub-mode.
+	textPunctChars = ";<>@[\\]_`~!\r\t,:\n-.$/\"|*()?{}'"
+)
+
+// textCompaction decodes the codewords starting at i using the text
+// compaction mode and writes the result to sb. The index of the first
+// codeword that is not part of the text compaction mode is returned.
+func textCompaction(codewords []int, i int, sb *strings.Builder) int {
+	mode, prior := textAlpha, textAlpha
+	for ; i < len(codewords); i++ {
+		cw := codewords[i]
+		switch {
+		case cw == pdf417TextLatch:
+			mode = textAlpha
+			continue
+		case cw == pdf417ByteShift:
+			if i+1 < len(codewords) {
+				sb.WriteByte(byte(codewords[i+1]))
+				i++
+			}
+			continue
+		case cw > pdf417TextLatch:
+			return i
+		}
+
+		// Each codeword encodes 2 values between 0 and 29.
+		for _, v := range [2]int{cw / 30, cw % 30} {
+			switch mode {
+			case textAlpha, textLower:
+				switch {
+				case v < 26 && mode == textAlpha:
+					sb.WriteByte(byte('A' + v))
+				case v < 26:
+					sb.WriteByte(byte('a' + v))
+				case v == 26:
+					sb.WriteByte(' ')
+				case v == 27 && mode == textAlpha:
+					mode = textLower
+				case v == 27:
+					prior, mode = mode, textAlphaShift
+				case v == 28:
+					mode = textMixed
+				case v == 29:
+					prior, mode = mode, textPunctShift
+				}
+			case textMixed:
+				switch {
+				case v < 25:
+					sb.WriteByte(textMixedChars[v])
+				case v == 25:
+					mode = textPunct
+				case v == 26:
+					sb.WriteByte(' ')
+				case v == 27:
+					mode = textLower
+				case v == 28:
+					mode = textAlpha
+				case v == 29:
+					prior, mode = mode, textPunctShift
+				}
+			case textPunct:
+				if v < 29 {
+					sb.WriteByte(textPunctChars[v])
+				} else {
+					mode = textAlpha
+				}
+			case textAlphaShift:
+				mode = prior
+				switch {
+				case v < 26:
+					sb.WriteByte(byte('A' + v))
+				case v == 26:
+					sb.WriteByte(' ')
+				}
+			case textPunctShift:
+				mode = prior
+				if v < 29 {
+					sb.WriteByte(textPunctChars[v])
+				} else {
+					mode = textAlpha
+				}
+			}
+		}
+	}
+	return i
+}
+
+// byteCompaction decodes the codewords starting at i using the byte
+// compaction mode latched by latch and writes the result to sb. The index
+// of the first codeword that is not part of the byte compaction mode is
+// returned.
+//
+// Groups of 5 codewords encode 6 bytes. When latched using 901, a trailing
+// group of less than 6 bytes is encoded using a codeword per byte.
+func byteCompaction(latch int, codewords []int, i int, sb *strings.Builder) int {
+	for i < len(codewords) && codewords[i] < pdf417TextLatch {
+		var value uint64
+		count := 0
+		for count < 5 && i+count < len(codewords) && codewords[i+count] < pdf417TextLatch {
+			value = 900*value + uint64(codewords[i+count])
+			count++
+		}
+
+		more := i+count < len(codewords) && codewords[i+count] < pdf417TextLatch
+		if count == 5 && (latch == pdf417ByteLatch6 || more) {
+			for j := 5; j >= 0; j-- {
+				sb.WriteByte(byte(value >> (8 * j)))
+			}
+			i += count
+			continue
+		}
+
+		for ; i < len(codewords) && codewords[i] < pdf417TextLatch; i++ {
+			sb.WriteByte(byte(codewords[i]))
+		}
+	}
+	return i
+}
+
+// numericCompaction decodes the codewords starting at i using the numeric
+// compaction mode and writes the result to sb. The index of the first
+// codeword that is not part of the numeric compaction mode is returned.
+//
+// Groups of up to 15 codewords encode a base 900 number prefixed with 1.
+func numericCompaction(codewords []int, i int, sb *strings.Builder) (int, error) {
+	for i < len(codewords) && codewords[i] < pdf417TextLatch {
+		value := new(big.Int)
+		for count := 0; count < 15 && i < len(codewords) && codewords[i] < pdf417TextLatch; count++ {
+			value.Mul(value, big.NewInt(900))
+			value.Add(value, big.NewInt(int64(codewords[i])))
+			i++
+		}
+
+		digits := value.String()
+		if digits[0] != '1' {
+			return i, errPDF417Format
+		}
+		sb.WriteString(digits[1:])
+	}
+
+	// A numeric latch following a group starts a new group.
+	if i < len(codewords) && codewords[i] == pdf417NumericLatch {
+		return numericCompaction(codewords, i+1, sb)
+	}
+	return i, nil
+}
@@ -0,0 +1,218 @@
+package barcode
+
+// PDF417 error correction codewords are Reed-Solomon codes over the prime
+// field GF(929) using 3 as the generator.
+const (
+	gfSize      = 929
+	gfGenerator = 3
+)
+
+var gfExp, gfLog = func() (exp, log [gfSize]int) {
+	x := 1
+	for i := 0; i < gfSize; i++ {
+		exp[i] = x
+		x = x * gfGenerator % gfSize
+	}
+	for i := 0; i < gfSize-1; i++ {
+		log[exp[i]] = i
+	}
+	return exp, log
+}()
+
+func gfAdd(a, b int) int {
+	return (a + b) % gfSize
+}
+
+func gfSub(a, b int) int {
+	return (gfSize + a - b) % gfSize
+}
+
+func gfMul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[(gfLog[a]+gfLog[b])%(gfSize-1)]
+}
+
+func gfInv(a int) int {
+	return gfExp[gfSize-1-gfLog[a]]
+}
+
+// gfPoly is a polynomial over GF(929). Coefficients are ordered from the
+// highest degree term to the constant term and have no leading zeroes.
+type gfPoly []int
+
+// newGFPoly returns the polynomial with coefficients c stripped of leading
+// zeroes.
+func newGFPoly(c []int) gfPoly {
+	for len(c) > 1 && c[0] == 0 {
+		c = c[1:]
+	}
+	return gfPoly(c)
+}
+
+// gfMonomial returns coefficient * x^degree.
+func gfMonomial(degree, coefficient int) gfPoly {
+	if coefficient == 0 {
+		return gfPoly{0}
+	}
+	p := make([]int, degree+1)
+	p[0] = coefficient
+	return p
+}
+
+func (p gfPoly) degree() int {
+	return len(p) - 1
+}
+
+func (p gfPoly) isZero() bool {
+	return p[0] == 0
+}
+
+// coefficient returns the coefficient of the term of degree d.
+func (p gfPoly) coefficient(d int) int {
+	return p[len(p)-1-d]
+}
+
+// eval evaluates p at a.
+func (p gfPoly) eval(a int) int {
+	var result int
+	for _, c := range p {
+		result = gfAdd(gfMul(a, result), c)
+	}
+	return result
+}
+
+func (p gfPoly) add(q gfPoly) gfPoly {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	sum := make([]int, len(p))
+	diff := len(p) - len(q)
+	copy(sum, p[:diff])
+	for i := diff; i < len(p); i++ {
+		sum[i] = gfAdd(p[i], q[i-diff])
+	}
+	return newGFPoly(sum)
+}
+
+func (p gfPoly) negative() gfPoly {
+	neg := make([]int, len(p))
+	for i, c := range p {
+		neg[i] = gfSub(0, c)
+	}
+	return neg
+}
+
+func (p gfPoly) sub(q gfPoly) gfPoly {
+	return p.add(q.negative())
+}
+
+func (p gfPoly) mul(q gfPoly) gfPoly {
+	if p.isZero() || q.isZero() {
+		return gfPoly{0}
+	}
+	product := make([]int, len(p)+len(q)-1)
+	for i, a := range p {
+		for j, b := range q {
+			product[i+j] = gfAdd(product[i+j], gfMul(a, b))
+		}
+	}
+	return newGFPoly(product)
+}
+
+func (p gfPoly) scale(s int) gfPoly {
+	return p.mul(gfPoly{s})
+}
+
+// correctPDF417 corrects the errors of codewords in place. The last numEC
+// codewords are the error correction codewords.
+func correctPDF417(codewords []int, numEC int) error {
+	received := newGFPoly(codewords)
+	syndromes := make([]int, numEC)
+	var hasErrors bool
+	for i := numEC; i > 0; i-- {
+		s := received.eval(gfExp[i])
+		syndromes[numEC-i] = s
+		if s != 0 {
+			hasErrors = true
+		}
+	}
+	if !hasErrors {
+		return nil
+	}
+
+	sigma, omega, err := gfEuclidean(gfMonomial(numEC, 1), newGFPoly(syndromes), numEC)
+	if err != nil {
+		return err
+	}
+
+	// Chien search for the roots of the error locator.
+	var locations []int
+	for i := 1; i < gfSize && len(locations) < sigma.degree(); i++ {
+		if sigma.eval(i) == 0 {
+			locations = append(locations, gfInv(i))
+		}
+	}
+	if len(locations) != sigma.degree() {
+		return errPDF417Checksum
+	}
+
+	// Forney's algorithm for the error magnitudes.
+	derivative := make([]int, sigma.degree())
+	for i := 1; i <= sigma.degree(); i++ {
+		derivative[sigma.degree()-i] = gfMul(i, sigma.coefficient(i))
+	}
+	formal := newGFPoly(derivative)
+	for _, loc := range locations {
+		inv := gfInv(loc)
+		denominator := formal.eval(inv)
+		if denominator == 0 {
+			return errPDF417Checksum
+		}
+		magnitude := gfMul(gfSub(0, omega.eval(inv)), gfInv(denominator))
+
+		pos := len(codewords) - 1 - gfLog[loc]
+		if pos < 0 {
+			return errPDF417Checksum
+		}
+		codewords[pos] = gfSub(codewords[pos], magnitude)
+	}
+	return nil
+}
+
+// gfEuclidean runs the extended Euclidean algorithm on a and b and returns
+// the error locator and error evaluator polynomials.
+func gfEuclidean(a, b gfPoly, r int) (sigma, omega gfPoly, err error) {
+	if a.degree() < b.degree() {
+		a, b = b, a
+	}
+
+	rLast, rCur := a, b
+	tLast, tCur := gfPoly{0}, gfPoly{1}
+	for rCur.degree() >= r/2 {
+		rLastLast, tLastLast := rLast, tLast
+		rLast, tLast = rCur, tCur
+		if rLast.isZero() {
+			return nil, nil, errPDF417Checksum
+		}
+
+		rCur = rLastLast
+		q := gfPoly{0}
+		dltInverse := gfInv(rLast.coefficient(rLast.degree()))
+		for rCur.degree() >= rLast.degree() && !rCur.isZero() {
+			diff := rCur.degree() - rLast.degree()
+			s := gfMul(rCur.coefficient(rCur.degree()), dltInverse)
+			q = q.add(gfMonomial(diff, s))
+			rCur = rCur.sub(gfMonomial(diff, s).mul(rLast))
+		}
+		tCur = q.mul(tLast).sub(tLastLast).negative()
+	}
+
+	sigmaAtZero := tCur.coefficient(0)
+	if sigmaAtZero == 0 {
+		return nil, nil, errPDF417Checksum
+	}
+	inv := gfInv(sigmaAtZero)
+	return tCur.scale(inv), rCur.scale(inv), nil
+}
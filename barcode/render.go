@@ -0,0 +1,245 @@
+package barcode
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"github.com/jandauz/boarding-pass"
+
+	bc "github.com/boombuler/barcode"
+	bcaztec "github.com/boombuler/barcode/aztec"
+	bcdatamatrix "github.com/boombuler/barcode/datamatrix"
+	bcpdf417 "github.com/boombuler/barcode/pdf417"
+	bcqr "github.com/boombuler/barcode/qr"
+)
+
+// RenderOption configures how a barcode is rendered.
+type RenderOption func(*renderOptions)
+
+// renderOptions holds the configuration used while rendering a barcode.
+type renderOptions struct {
+	// moduleSize is the width, in pixels, of a module.
+	moduleSize int
+
+	// quietZone is the width, in modules, of the margin around the barcode.
+	quietZone int
+
+	// errorCorrection is the error correction level. Its meaning depends on
+	// the symbology.
+	errorCorrection int
+}
+
+// symbologyDefaults are the options used to render each symbology unless
+// overridden. They are suited to boarding passes:
+//   PDF417      - security level 5 and a quiet zone of 2 modules
+//   Aztec       - 23% error correction and no quiet zone
+//   QR Code     - error correction level M and a quiet zone of 4 modules
+//   Data Matrix - a quiet zone of 1 module
+var symbologyDefaults = map[Symbology]renderOptions{
+	PDF417:     {moduleSize: 3, quietZone: 2, errorCorrection: 5},
+	Aztec:      {moduleSize: 3, quietZone: 0, errorCorrection: 23},
+	QRCode:     {moduleSize: 3, quietZone: 4, errorCorrection: int(bcqr.M)},
+	DataMatrix: {moduleSize: 3, quietZone: 1},
+}
+
+// pdf417RowHeight is the height, in modules, of a row of a PDF417 barcode.
+const pdf417RowHeight = 3
+
+// WithModuleSize sets the width, in pixels, of a module, i.e. the narrowest
+// bar or space of the barcode. It defaults to 3 pixels.
+func WithModuleSize(pixels int) RenderOption {
+	return func(o *renderOptions) {
+		o.moduleSize = pixels
+	}
+}
+
+// WithQuietZone sets the width, in modules, of the light margin around the
+// barcode.
+func WithQuietZone(modules int) RenderOption {
+	return func(o *renderOptions) {
+		o.quietZone = modules
+	}
+}
+
+// WithErrorCorrection sets the error correction level of the barcode. Its
+// meaning depends on the symbology:
+//   PDF417      - the security level between 0 and 8
+//   Aztec       - the minimum percentage of error correction codewords
+//   QR Code     - 0 for L, 1 for M, 2 for Q, and 3 for H
+//   Data Matrix - not applicable, ECC 200 is always used
+func WithErrorCorrection(level int) RenderOption {
+	return func(o *renderOptions) {
+		o.errorCorrection = level
+	}
+}
+
+// Symbol is a barcode rendered from Bar Coded Boarding Pass data. It
+// implements image.Image.
+type Symbol struct {
+	// Symbology is the symbology of the barcode.
+	Symbology Symbology
+
+	// modules holds whether each module of the barcode, excluding the quiet
+	// zone, is dark.
+	modules [][]bool
+
+	// rowHeight is the height, in modules, of a row of modules.
+	rowHeight int
+
+	opts renderOptions
+}
+
+var _ image.Image = &Symbol{}
+
+// Render encodes b and renders it as a barcode of symbology sym.
+func Render(b *bcbp.BCBP, sym Symbology, opts ...RenderOption) (*Symbol, error) {
+	s, err := b.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return RenderString(s, sym, opts...)
+}
+
+// RenderString renders the Bar Coded Boarding Pass data s as a barcode of
+// symbology sym. s is not validated.
+func RenderString(s string, sym Symbology, opts ...RenderOption) (*Symbol, error) {
+	o, ok := symbologyDefaults[sym]
+	if !ok {
+		return nil, fmt.Errorf("barcode: unsupported symbology %v", sym)
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.moduleSize < 1 || o.quietZone < 0 {
+		return nil, fmt.Errorf("barcode: invalid module size %d or quiet zone %d", o.moduleSize, o.quietZone)
+	}
+
+	var code bc.Barcode
+	var err error
+	rowHeight := 1
+	switch sym {
+	case PDF417:
+		if o.errorCorrection < 0 || o.errorCorrection > 8 {
+			return nil, fmt.Errorf("barcode: invalid PDF417 security level %d", o.errorCorrection)
+		}
+		code, err = bcpdf417.Encode(s, byte(o.errorCorrection))
+		rowHeight = pdf417RowHeight
+	case Aztec:
+		code, err = bcaztec.Encode([]byte(s), o.errorCorrection, 0)
+	case QRCode:
+		if o.errorCorrection < int(bcqr.L) || o.errorCorrection > int(bcqr.H) {
+			return nil, fmt.Errorf("barcode: invalid QR Code error correction level %d", o.errorCorrection)
+		}
+		code, err = bcqr.Encode(s, bcqr.ErrorCorrectionLevel(o.errorCorrection), bcqr.Auto)
+	case DataMatrix:
+		code, err = bcdatamatrix.Encode(s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("barcode: cannot render %v: %w", sym, err)
+	}
+
+	return &Symbol{
+		Symbology: sym,
+		modules:   modules(code, sym),
+		rowHeight: rowHeight,
+		opts:      o,
+	}, nil
+}
+
+// modules returns whether each module of code is dark. code is rendered
+// using 1 pixel per module except for the rows of PDF417 barcodes which are
+// 2 pixels high.
+func modules(code bc.Barcode, sym Symbology) [][]bool {
+	step := 1
+	if sym == PDF417 {
+		step = 2
+	}
+
+	b := code.Bounds()
+	var m [][]bool
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		row := make([]bool, b.Dx())
+		for x := range row {
+			row[x] = color.GrayModel.Convert(code.At(b.Min.X+x, y)).(color.Gray).Y < 0x80
+		}
+		m = append(m, row)
+	}
+	return m
+}
+
+// ColorModel implements the image.Image interface.
+func (s *Symbol) ColorModel() color.Model {
+	return color.GrayModel
+}
+
+// Bounds implements the image.Image interface. It includes the quiet zone.
+func (s *Symbol) Bounds() image.Rectangle {
+	w, h := s.size()
+	return image.Rect(0, 0, w*s.opts.moduleSize, h*s.opts.moduleSize)
+}
+
+// At implements the image.Image interface.
+func (s *Symbol) At(x, y int) color.Color {
+	if s.dark(x/s.opts.moduleSize, y/s.opts.moduleSize) {
+		return color.Gray{Y: 0}
+	}
+	return color.Gray{Y: 0xff}
+}
+
+// size returns the width and height, in modules, of s including the quiet
+// zone.
+func (s *Symbol) size() (int, int) {
+	q := s.opts.quietZone
+	return len(s.modules[0]) + 2*q, len(s.modules)*s.rowHeight + 2*q
+}
+
+// dark reports whether the module at x and y, including the quiet zone, is
+// dark.
+func (s *Symbol) dark(x, y int) bool {
+	x -= s.opts.quietZone
+	y -= s.opts.quietZone
+	if x < 0 || y < 0 || x >= len(s.modules[0]) || y >= len(s.modules)*s.rowHeight {
+		return false
+	}
+	return s.modules[y/s.rowHeight][x]
+}
+
+// EncodePNG writes s to w in PNG format.
+func (s *Symbol) EncodePNG(w io.Writer) error {
+	return png.Encode(w, s)
+}
+
+// EncodeSVG writes s to w in SVG format. Dark modules are drawn as a single
+// path using a coordinate system where a module is 1 unit wide. The width and
+// height of the SVG are in pixels.
+func (s *Symbol) EncodeSVG(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	mw, mh := s.size()
+	fmt.Fprintf(bw,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		mw*s.opts.moduleSize, mh*s.opts.moduleSize, mw, mh)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="#fff"/>`+"\n", mw, mh)
+	bw.WriteString(`<path fill="#000" d="`)
+
+	// Consecutive dark modules of a row are drawn as a single rectangle.
+	q := s.opts.quietZone
+	for y, row := range s.modules {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(bw, "M%d %dh%dv%dh-%dz", start+q, y*s.rowHeight+q, x-start, s.rowHeight, x-start)
+		}
+	}
+
+	bw.WriteString(`"/>` + "\n</svg>\n")
+	return bw.Flush()
+}
@@ -0,0 +1,124 @@
+package barcode
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/jandauz/boarding-pass"
+)
+
+func TestRender(t *testing.T) {
+	data, err := os.ReadFile("../testdata/full_multi.input")
+	if err != nil {
+		t.Fatalf("failed reading .input file: %v", err)
+	}
+
+	b, err := bcbp.FromStr(string(data))
+	if err != nil {
+		t.Fatalf("FromStr(%s) returned unexpected error: %+v", data, err)
+	}
+	want, err := b.Encode()
+	if err != nil {
+		t.Fatalf("Encode() returned unexpected error: %+v", err)
+	}
+
+	for _, sym := range []Symbology{PDF417, Aztec, QRCode, DataMatrix} {
+		t.Run(sym.String(), func(t *testing.T) {
+			s, err := Render(&b, sym)
+			if err != nil {
+				t.Fatalf("Render() returned unexpected error: %+v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := s.EncodePNG(&buf); err != nil {
+				t.Fatalf("EncodePNG() returned unexpected error: %+v", err)
+			}
+			img, err := png.Decode(&buf)
+			if err != nil {
+				t.Fatalf("png.Decode() returned unexpected error: %+v", err)
+			}
+
+			gotSym, got, err := Read(img)
+			if err != nil {
+				t.Fatalf("Read() returned unexpected error: %+v", err)
+			}
+			if gotSym != sym || got != want {
+				t.Errorf("Read() = %v, %q, want %v, %q", gotSym, got, sym, want)
+			}
+		})
+	}
+}
+
+func TestSymbol_EncodeSVG(t *testing.T) {
+	s, err := RenderString(mandatory, PDF417, WithModuleSize(2), WithQuietZone(1))
+	if err != nil {
+		t.Fatalf("RenderString() returned unexpected error: %+v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := s.EncodeSVG(&buf); err != nil {
+		t.Fatalf("EncodeSVG() returned unexpected error: %+v", err)
+	}
+
+	var svg struct {
+		XMLName xml.Name
+		Width   int    `xml:"width,attr"`
+		Height  int    `xml:"height,attr"`
+		ViewBox string `xml:"viewBox,attr"`
+		Path    struct {
+			D string `xml:"d,attr"`
+		} `xml:"path"`
+	}
+	if err := xml.NewDecoder(&buf).Decode(&svg); err != nil && err != io.EOF {
+		t.Fatalf("xml.Decode() returned unexpected error: %+v", err)
+	}
+
+	bounds := s.Bounds()
+	if svg.XMLName.Local != "svg" || svg.Width != bounds.Dx() || svg.Height != bounds.Dy() {
+		t.Errorf("EncodeSVG() = <%s width=%d height=%d>, want <svg width=%d height=%d>",
+			svg.XMLName.Local, svg.Width, svg.Height, bounds.Dx(), bounds.Dy())
+	}
+	if svg.Path.D == "" {
+		t.Error("EncodeSVG() path is empty")
+	}
+}
+
+func TestRenderString_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		sym  Symbology
+		opts []RenderOption
+	}{
+		{
+			name: "unknown symbology",
+			sym:  Symbology(0),
+		},
+		{
+			name: "module size",
+			sym:  QRCode,
+			opts: []RenderOption{WithModuleSize(0)},
+		},
+		{
+			name: "pdf417 security level",
+			sym:  PDF417,
+			opts: []RenderOption{WithErrorCorrection(9)},
+		},
+		{
+			name: "qr code error correction level",
+			sym:  QRCode,
+			opts: []RenderOption{WithErrorCorrection(4)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RenderString(mandatory, tt.sym, tt.opts...); err == nil {
+				t.Error("RenderString() = nil: expected error")
+			}
+		})
+	}
+}
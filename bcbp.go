@@ -2,17 +2,31 @@ package bcbp
 
 import (
 	"encoding/json"
+	"reflect"
 	"strconv"
 	"strings"
-	"time"
 	"unicode"
 	"unicode/utf8"
 	"unsafe"
 )
 
+const (
+	// FormatCodeMultiple is the format code of a multiple-leg Bar Coded
+	// Boarding Pass. It can encode up to 4 legs.
+	FormatCodeMultiple = "M"
+
+	// FormatCodeSingle is the format code of a single-leg Bar Coded Boarding
+	// Pass. It is a legacy format that has been superseded by
+	// FormatCodeMultiple. It is decoded using the same layout as
+	// FormatCodeMultiple, however, it can only encode 1 leg.
+	FormatCodeSingle = "S"
+)
+
 // BCBP is a structured representation of an IATA 792 Bar Coded Boarding Pass.
 type BCBP struct {
-	// FormatCode is the format of the BCBP. M for multiple.
+	// FormatCode is the format of the BCBP. M for multiple-leg; S for the
+	// deprecated single-leg format. See FormatCodeMultiple and
+	// FormatCodeSingle.
 	FormatCode string `json:"format_code"`
 
 	// NumberOfLegsEncoded is the number of flight segments encoded on
@@ -27,11 +41,13 @@ type BCBP struct {
 	// followed by a "/" and one alpha initial.
 	//
 	// The formatting is left justified with trailing whitespaces.
-	PassengerName string `json:"passenger_name"`
+	//
+	// See PassengerName for splitting the name into its parts.
+	PassengerName PassengerName `json:"passenger_name"`
 
 	// ElectronicTicketIndicator is a flag that indicates whether or not
 	// the boarding pass is issued against an electronic ticket. E or L.
-	ElectronicTicketIndicator string `json:"electronic_ticket_indicator"`
+	ElectronicTicketIndicator ElectronicTicketIndicator `json:"electronic_ticket_indicator"`
 
 	// Version number is the version of IATA 792 spec that is used to encode
 	// the barcode. The latest version is 8.
@@ -51,19 +67,20 @@ type BCBP struct {
 	//   U - Undisclosed
 	//
 	//   Values 8-9 and A-T, V, W, Y, and Z are reserved for future industry use.
-	PassengerDescription string `json:"passenger_description,omitempty"`
+	PassengerDescription PassengerDescription `json:"passenger_description,omitempty"`
 
 	// SourceOfCheckin is where the check-in was initiated. It can be one of
 	// the following values:
 	//   W - Web
 	//   K - Airport kiosk
+	//   X - Transfer kiosk
 	//   R - Remote or off site kiosk
 	//   M - Mobile device
 	//   O - Airport agent
 	//   T - Town agent
 	//   V - Third party vendor
 	//   A - Automated check-in
-	SourceOfCheckIn string `json:"source_of_check_in,omitempty"`
+	SourceOfCheckIn SourceOfCheckIn `json:"source_of_check_in,omitempty"`
 
 	// SourceOfBoardingPassIssuance is where the boarding pass was issued.
 	// It can be one of the following values:
@@ -75,7 +92,7 @@ type BCBP struct {
 	//   O - Airport agent printed
 	//   T - Town agent printed
 	//   V - Third party vendor printed
-	SourceOfBoardingPassIssuance string `json:"source_of_boarding_pass_issuance,omitempty"`
+	SourceOfBoardingPassIssuance SourceOfBoardingPassIssuance `json:"source_of_boarding_pass_issuance,omitempty"`
 
 	// DateOfIssueOfBoarding pass is the date the boarding pass was issued
 	// include the last digit of the year in Julian Date.
@@ -83,11 +100,14 @@ type BCBP struct {
 	// in Julian Date would be 1001.
 	//
 	// See https://en.wikipedia.org/wiki/Julian_day for more information.
-	DateOfIssueOfBoardingPass string `json:"date_of_issue_of_boarding_pass,omitempty"`
+	//
+	// The encoded Julian Date is resolved to a full date. Use
+	// Date.JulianDate to get the encoded Julian Date back.
+	DateOfIssueOfBoardingPass Date `json:"date_of_issue_of_boarding_pass,omitempty"`
 
 	// DocumentType is the type of travel document provided.
 	// B for boarding pass; I for itinerary receipt.
-	DocumentType string `json:"document_type,omitempty"`
+	DocumentType DocumentType `json:"document_type,omitempty"`
 
 	// AirlineDesignatorOfBoardingPassIssuer is the airline code of the airline
 	// that issued the boarding pass.
@@ -128,16 +148,35 @@ type BCBP struct {
 	// data is the data encoded on a Bar Coded Boarding Pass.
 	data string
 
-	// dateBuf is used as a buffer when using time.AppendFormat() to convert
-	// Julian dates into RFC3339 full-date formats (2006-01-02).
-	//
-	// See https://segment.com/blog/allocation-efficiency-in-high-performance-go-services/
-	// for more information.
-	dateBuf []byte
+	// signedLen is the length of the data that precedes the security
+	// section of data.
+	signedLen int
 
 	// pos is the starting index of the character being processed in data.
 	// This is used by whitespace() for pretty printing error reports.
 	pos int
+
+	// opts is the configuration used while decoding data.
+	opts options
+
+	// layoutVersion is the version of the layout used to decode the
+	// conditional items of data.
+	layoutVersion uint
+
+	// errs holds the errors of items that are invalid when decoding data
+	// using WithLenientDecoding.
+	errs DecodeErrors
+
+	// fields holds every item decoded from data when options.fields is set.
+	fields []Field
+}
+
+// LayoutVersion returns the version of the IATA 792 specification whose
+// layout was used to decode the conditional items of b. Items introduced in
+// a later version are not decoded. It is 0 if b does not have conditional
+// items.
+func (b BCBP) LayoutVersion() uint {
+	return b.layoutVersion
 }
 
 // Legs is an array of 4 Leg.
@@ -193,8 +232,9 @@ type Leg struct {
 	// FlightNumber is the number of the flight.
 	//
 	// The formatting is up to 4 digits with leading zeroes followed by an
-	// optional alpha suffix or whitespace.
-	FlightNumber string `json:"flight_number"`
+	// optional alpha suffix or whitespace. See Leg.Flight for combining it with
+	// OperatingCarrierDesignator.
+	FlightNumber FlightNumber `json:"flight_number"`
 
 	// DateOfFlight is the scheduled flight date in Julian Date. The date
 	// is expressed in the number of days (inclusive) from January 1.
@@ -203,7 +243,10 @@ type Leg struct {
 	// See https://en.wikipedia.org/wiki/Julian_day for more information.
 	//
 	// The formatting is numerical with leading zeroes.
-	DateOfFlight string `json:"date_of_flight"`
+	//
+	// The encoded Julian Date is resolved to a full date. Use
+	// Date.JulianDate to get the encoded Julian Date back.
+	DateOfFlight Date `json:"date_of_flight"`
 
 	// CompartmentCode is the code of the compartment also know as the
 	// Cabin Type.
@@ -245,15 +288,17 @@ type Leg struct {
 	//   INF
 	//   GATE
 	//   STBY
-	SeatNumber string `json:"seat_number"`
+	// See Seat for accessing the row and letter of the seat.
+	SeatNumber Seat `json:"seat_number"`
 
 	// CheckInSequenceNumber is the order in which the passenger has checked-in
 	// for the flight.
 	//
 	// The formatting is 4 digits with leading zeroes followed by an optional
 	// alpha or whitespace. Infant passengers are an exception in which case
-	// 5 alphanumeric characters may be used.
-	CheckInSequenceNumber string `json:"check_in_sequence_number"`
+	// 5 alphanumeric characters may be used. See CheckInSequenceNumber for
+	// accessing the number and suffix.
+	CheckInSequenceNumber CheckInSequenceNumber `json:"check_in_sequence_number"`
 
 	// PassengerStatus is the status of the passenger.
 	//
@@ -301,7 +346,7 @@ type Leg struct {
 	//   0 - Not selectee
 	//   1 - Selectee
 	//   2 - Known passenger
-	SelecteeIndicator string `json:"selectee_indicator,omitempty"`
+	SelecteeIndicator SelecteeIndicator `json:"selectee_indicator,omitempty"`
 
 	// InternationalDocumentationVerification is a flag that is used carriers
 	// to identify passengers requiring their travel documentation to be
@@ -311,7 +356,7 @@ type Leg struct {
 	//   0 - Travel documentation verification required
 	//   1 - Travel documentation verification not required
 	//   2 - Travel documentation verification performed
-	InternationalDocumentationVerification string `json:"international_documentation_verification,omitempty"`
+	InternationalDocumentationVerification InternationalDocumentationVerification `json:"international_documentation_verification,omitempty"`
 
 	// MarketingCarrierDesignator is the airline code of the marketing carrier.
 	// It can be the same as OperatingCarrierDesignator. Two or three character
@@ -357,12 +402,13 @@ type Leg struct {
 	//   E - IDR1
 	//
 	// Values G-Z are reserved for future industry use.
-	IDADIndicator string `json:"idad_indicator,omitempty"`
+	IDADIndicator IDADIndicator `json:"idad_indicator,omitempty"`
 
 	// FreeBaggageAllowance specifies the weight, either in K (kilos) or
 	// L (pounds), or PC (number of pieces).
 	//
-	// For example, it can be 20K, 40L, or 2PC.
+	// For example, it can be 20K, 40L, or 2PC. See Leg.BaggageAllowance for
+	// accessing the amount and unit of the allowance.
 	FreeBaggageAllowance string `json:"free_baggage_allowance,omitempty"`
 
 	// FastTrack is a flag that specifies if the passenger is entitled to use
@@ -373,31 +419,156 @@ type Leg struct {
 	//   N - No
 	//
 	// A whitespace means unqualified.
-	FastTrack string `json:"fast_track,omitempty"`
+	FastTrack FastTrack `json:"fast_track,omitempty"`
 
 	// ForIndividualAirlineUse is a special field that airlines may use to
 	// populate with different entries such as but not limited to:
 	//   Frequent flyer tier
 	//   Passenger preferences
 	ForIndividualAirlineUse string `json:"for_individual_airline_use,omitempty"`
+
+	// FromAirport is the airport of FromCityAirportCode. It is only set when
+	// decoding using WithReferenceData.
+	FromAirport *Airport `json:"from_airport,omitempty"`
+
+	// ToAirport is the airport of ToCityAirportCode. It is only set when
+	// decoding using WithReferenceData.
+	ToAirport *Airport `json:"to_airport,omitempty"`
+
+	// OperatingCarrier is the airline of OperatingCarrierDesignator. It is
+	// only set when decoding using WithReferenceData.
+	OperatingCarrier *Airline `json:"operating_carrier,omitempty"`
 }
 
 // FromStr creates a new BCBP from s.
 func FromStr(s string) (BCBP, error) {
+	return FromStrWithOptions(s)
+}
+
+// FromStrWithOptions creates a new BCBP from s using the given options.
+//
+// By default, decoding stops at the first error and a *DecodeError is
+// returned. If WithLenientDecoding is used, decoding continues past items
+// that are invalid and DecodeErrors holding every error is returned.
+func FromStrWithOptions(s string, opts ...Option) (BCBP, error) {
+	b := BCBP{opts: newOptions(opts...)}
+	err := b.decodeWithOptions(s)
+	return b, err
+}
+
+// DecodeInto decodes s into dst using the given options. dst is reset before
+// decoding. Errors are returned similar to FromStrWithOptions.
+//
+// Unlike FromStrWithOptions, DecodeInto reuses the storage of dst and does
+// not allocate when s is successfully decoded. The fields of dst reference s
+// unless WithCopy is used.
+func DecodeInto(dst *BCBP, s string, opts ...Option) error {
+	*dst = BCBP{opts: defaultOptions()}
+	for _, opt := range opts {
+		opt(&dst.opts)
+	}
+	return dst.decodeWithOptions(s)
+}
+
+// DecodeBytesInto is similar to DecodeInto but decodes data. data is not
+// copied unless WithCopy is used; otherwise the fields of dst reference data,
+// which must not be modified while dst is in use.
+func DecodeBytesInto(dst *BCBP, data []byte, opts ...Option) error {
+	return DecodeInto(dst, bytesToString(data), opts...)
+}
+
+// bytesToString returns data as a string without copying it.
+func bytesToString(data []byte) string {
+	return *(*string)(unsafe.Pointer(&data))
+}
+
+// decodeWithOptions decodes s into b using b.opts. Errors are collected into
+// DecodeErrors when using WithLenientDecoding.
+func (b *BCBP) decodeWithOptions(s string) error {
+	// Copy s so that the fields of b do not reference it.
+	if b.opts.copy {
+		var sb strings.Builder
+		sb.WriteString(s)
+		s = sb.String()
+	}
+
+	err := b.decode(s)
+	if !b.opts.lenient {
+		return err
+	}
+
+	// Errors that stop decoding are always a *DecodeError.
+	if err != nil {
+		b.errs = append(b.errs, err.(*DecodeError))
+	}
+	if len(b.errs) > 0 {
+		return b.errs
+	}
+	return nil
+}
+
+// decoded returns the data b was decoded from, or that Sign encoded, along
+// with the length of the data that precedes its security section. ok is false
+// if b was not decoded or if the data no longer decodes to the fields of b,
+// i.e. b was modified since.
+//
+// When using WithLenientDecoding, the data may hold the invalid items that
+// were reported when decoding b.
+func (b *BCBP) decoded() (data string, signedLen int, ok bool) {
+	if b.data == "" {
+		return "", 0, false
+	}
+
+	d := BCBP{opts: b.opts}
+	d.opts.copy = false
+	d.opts.fields = false
+	if err := d.decode(b.data); err != nil {
+		if !d.opts.lenient || len(d.errs) == 0 || len(d.errs) != len(b.errs) {
+			return "", 0, false
+		}
+	}
+	if !reflect.DeepEqual(b.withoutState(), d.withoutState()) {
+		return "", 0, false
+	}
+	return b.data, d.signedLen, true
+}
+
+// withoutState returns the fields of b without the state kept from decoding
+// or signing it.
+func (b BCBP) withoutState() BCBP {
+	b.data = ""
+	b.signedLen = 0
+	b.pos = 0
+	b.opts = options{}
+	b.layoutVersion = 0
+	b.errs = nil
+	b.fields = nil
+	return b
+}
+
+// decode checks that s can be decoded before decoding it into b.
+func (b *BCBP) decode(s string) error {
 	if len(s) < 60 {
-		return BCBP{}, InsufficientData(s, len(s))
+		return InsufficientData(s, len(s))
 	}
 
 	if pos, ok := ascii(s); !ok {
 		val, _ := utf8.DecodeRuneInString(s[pos:])
-		return BCBP{}, NonASCII(s, pos+1, val)
+		return NonASCII(s, pos+1, val)
 	}
 
-	if s[0:1] != "M" {
-		return BCBP{}, UnsupportedBoardingPass(s, s[0:1])
+	switch s[0:1] {
+	case FormatCodeMultiple:
+	case FormatCodeSingle:
+		// Single-leg boarding passes can only encode one leg.
+		if s[1:2] != "1" {
+			return InvalidDataFormat(s, 2, singleLegNumberOfLegsEncoded, s[1:2])
+		}
+	default:
+		return UnsupportedBoardingPass(s, s[0:1])
 	}
 
-	return fromStr(s)
+	return b.fromStr(s)
 }
 
 // ascii checks s to determine if it contains only ASCII characters.
@@ -412,24 +583,17 @@ func ascii(s string) (int, bool) {
 	return 0, true
 }
 
-func fromStr(s string) (BCBP, error) {
+func (b *BCBP) fromStr(s string) error {
 	if !spec[numberOfLegsEncoded].validate(s[1:2]) {
-		return BCBP{},
-			InvalidDataFormat(s, 2, spec[numberOfLegsEncoded], s[1:2])
+		return InvalidDataFormat(s, 2, spec[numberOfLegsEncoded], s[1:2])
 	}
 
 	// No need to check error as data validation happens above
 	legs, _ := strconv.Atoi(s[1:2])
 
-	// Dates use RFC-3339 full-date format. These are 10 bytes long.
-	// Allocate a 16 byte array, create a slice, and assign to dateBuf.
-	buf := [16]byte{}
-	b := BCBP{
-		data:                s,
-		NumberOfLegsEncoded: uint(legs),
-		dateBuf:             buf[:0],
-		pos:                 1,
-	}
+	b.data = s
+	b.NumberOfLegsEncoded = uint(legs)
+	b.pos = 1
 
 	// Iterate over the number of legs specified and recursively process the
 	// items defined in spec.
@@ -449,29 +613,31 @@ func fromStr(s string) (BCBP, error) {
 
 			processed, err := b.setFieldByItem(s, item, leg)
 			if err != nil {
-				return b, err
+				return err
 			}
 			s = s[processed:]
 		}
 	}
 
+	b.signedLen = len(b.data) - len(s)
+
 	// If len of s is 0 then there is nothing more to process.
 	if len(s) == 0 {
-		return b, nil
+		return nil
 	}
 
 	// Otherwise, there is more to process. However, if the prefix isn't the "^"
 	// character, which marks the beginning of security section, then return
 	// ErrProcessItemFailed.
 	if s[0:1] != "^" {
-		return b, InvalidDataFormat(b.data, b.pos, spec[fieldSizeOfVariableSizeField+1], s[0:1])
+		return InvalidDataFormat(b.data, b.pos, spec[fieldSizeOfVariableSizeField+1], s[0:1])
 	}
 
 	// Security items start after fieldSizeOfVariableSizeField in spec.
 	for _, item := range spec[fieldSizeOfVariableSizeField+1:] {
 		processed, err := b.setFieldByItem(s, item, 0)
 		if err != nil {
-			return b, err
+			return err
 		}
 		s = s[processed:]
 	}
@@ -480,9 +646,9 @@ func fromStr(s string) (BCBP, error) {
 	// be empty. If not, then that means the barcode data has extra unprocessed
 	// characters.
 	if s != "" {
-		return b, UnknownData(b.data, b.pos, s)
+		return UnknownData(b.data, b.pos, s)
 	}
-	return b, nil
+	return nil
 }
 
 // setFieldByItem sets the value of the appropriate field based on the item ID.
@@ -492,26 +658,8 @@ func (b *BCBP) setFieldByItem(s string, item item, leg int) (int, error) {
 	// Unique items appear only once in a Bar Coded Boarding Pass.
 	// Return immediately if the item is unique and current leg is
 	// greater than 0.
-	switch item.id {
-	case formatCode,
-		numberOfLegsEncoded,
-		passengerName,
-		electronicTicketIndicator,
-		beginningOfVersionNumber,
-		versionNumber,
-		fieldSizeOfFollowingStructuredMessageUnique,
-		passengerDescription,
-		sourceOfCheckin,
-		sourceOfBoardingPassIssuance,
-		dateOfIssueOfBoardingPass,
-		documentType,
-		airlineDesignatorOfBoardingPassIssuer,
-		baggageTagLicensePlateNumber,
-		firstNonConsecutiveBaggageTagLicensePlateNumber,
-		secondNonConsecutiveBaggageTagLicensePlateNumber:
-		if leg > 0 {
-			return 0, nil
-		}
+	if item.unique() && leg > 0 {
+		return 0, nil
 	}
 
 	itemLen := item.length
@@ -524,9 +672,52 @@ func (b *BCBP) setFieldByItem(s string, item item, leg int) (int, error) {
 		itemLen = len(s)
 	}
 
-	// Validate that the data matches the item's format.
-	if !item.validate(s[:itemLen]) {
-		return 0, InvalidDataFormat(b.data, b.pos, item, s[:itemLen])
+	// The data of the item is missing when the Bar Coded Boarding Pass is
+	// truncated, e.g. it encodes fewer legs than NumberOfLegsEncoded.
+	if len(s) < itemLen {
+		return 0, UnexpectedEndOfInput(b.data, b.pos, item, s, itemLen)
+	}
+
+	// Validate that the data matches the item's format and that Julian
+	// dates exist.
+	valid := item.validate(s[:itemLen])
+	var date Date
+	if valid {
+		date, valid = b.date(item, strings.TrimSpace(s[:itemLen]))
+	}
+	if !valid {
+		err := InvalidDataFormat(b.data, b.pos, item, s[:itemLen])
+
+		// In lenient mode, the error is collected and the item is skipped.
+		// Items that define a sub-section cannot be skipped since their
+		// value is needed to process the sub-section.
+		if !b.opts.lenient || item.items != nil {
+			return 0, err
+		}
+		b.errs = append(b.errs, err)
+		b.pos += itemLen
+		return itemLen, nil
+	}
+
+	// Validate that codes are known by the reference data.
+	if b.opts.referenceData != nil && !b.lookup(item, leg, strings.TrimSpace(s[:itemLen])) {
+		err := UnknownCode(b.data, b.pos, item, s[:itemLen])
+		if !b.opts.lenient {
+			return 0, err
+		}
+		b.errs = append(b.errs, err)
+		b.pos += itemLen
+		return itemLen, nil
+	}
+
+	if b.opts.fields {
+		b.fields = append(b.fields, Field{
+			Description: item.description,
+			Leg:         leg,
+			Offset:      b.pos - 1,
+			Length:      itemLen,
+			Raw:         s[:itemLen],
+		})
 	}
 
 	// Substring the value and assign to the appropriate BCBP field based on
@@ -536,9 +727,9 @@ func (b *BCBP) setFieldByItem(s string, item item, leg int) (int, error) {
 	case formatCode:
 		b.FormatCode = val
 	case passengerName:
-		b.PassengerName = val
+		b.PassengerName = PassengerName(val)
 	case electronicTicketIndicator:
-		b.ElectronicTicketIndicator = val
+		b.ElectronicTicketIndicator = ElectronicTicketIndicator(val)
 	case operatingCarrierPNRCode:
 		b.Legs[leg].OperatingCarrierPNRCode = val
 	case fromCityAirportCode:
@@ -548,60 +739,32 @@ func (b *BCBP) setFieldByItem(s string, item item, leg int) (int, error) {
 	case operatingCarrierDesignator:
 		b.Legs[leg].OperatingCarrierDesignator = val
 	case flightNumber:
-		b.Legs[leg].FlightNumber = val
+		b.Legs[leg].FlightNumber = FlightNumber(val)
 	case dateOfFlight:
-		// Re-slice dateBuf so that we append the date format at the start
-		// of the buffer instead of at the end.
-		b.dateBuf = b.dateBuf[:0]
-
-		// item.validate() ensures val is a number, no need to check error
-		d, _ := strconv.Atoi(val)
-		t := time.Date(time.Now().Year(), time.January, 0, 0, 0, 0, 0, time.UTC)
-		t = t.AddDate(0, 0, d)
-		b.dateBuf = t.AppendFormat(b.dateBuf, "2006-01-02")
-
-		// See https://github.com/golang/go/issues/25484#issuecomment-391415660.
-		// This copies strings.Builder.String() way of copying byte array to string.
-		b.Legs[leg].DateOfFlight = *(*string)(unsafe.Pointer(&b.dateBuf))
+		b.Legs[leg].DateOfFlight = date
 	case compartmentCode:
 		b.Legs[leg].CompartmentCode = val
 	case seatNumber:
-		b.Legs[leg].SeatNumber = val
+		b.Legs[leg].SeatNumber = Seat(val)
 	case checkinSequenceNumber:
-		b.Legs[leg].CheckInSequenceNumber = val
+		b.Legs[leg].CheckInSequenceNumber = CheckInSequenceNumber(s[:itemLen])
 	case passengerStatus:
 		b.Legs[leg].PassengerStatus = val
 	case versionNumber:
 		// item.validate() ensures val is a number, no need to check error
 		n, _ := strconv.Atoi(val)
 		b.VersionNumber = uint(n)
+		b.layoutVersion = layout(b.VersionNumber)
 	case passengerDescription:
-		b.PassengerDescription = val
+		b.PassengerDescription = PassengerDescription(val)
 	case sourceOfCheckin:
-		b.SourceOfCheckIn = val
+		b.SourceOfCheckIn = SourceOfCheckIn(val)
 	case sourceOfBoardingPassIssuance:
-		b.SourceOfBoardingPassIssuance = val
+		b.SourceOfBoardingPassIssuance = SourceOfBoardingPassIssuance(val)
 	case dateOfIssueOfBoardingPass:
-		// Re-slice dateBuf so that we append the date format at the start
-		// of the buffer instead of at the end.
-		b.dateBuf = b.dateBuf[:0]
-
-		// item.validate() ensures val is a number, no need to check error
-		y, _ := strconv.Atoi(val[:1])
-		n := time.Now().Year() % 10
-		y -= n
-
-		// item.validate() ensures val is a number
-		d, _ := strconv.Atoi(val[1:])
-		t := time.Date(time.Now().Year(), time.January, 0, 0, 0, 0, 0, time.UTC)
-		t = t.AddDate(y, 0, d)
-		b.dateBuf = t.AppendFormat(b.dateBuf, "2006-01-02")
-
-		// See https://github.com/golang/go/issues/25484#issuecomment-391415660.
-		// This copies strings.Builder.String() way of copying byte array to string.
-		b.DateOfIssueOfBoardingPass = *(*string)(unsafe.Pointer(&b.dateBuf))
+		b.DateOfIssueOfBoardingPass = date
 	case documentType:
-		b.DocumentType = val
+		b.DocumentType = DocumentType(val)
 	case airlineDesignatorOfBoardingPassIssuer:
 		b.AirlineDesignatorOfBoardingPassIssuer = val
 	case baggageTagLicensePlateNumber:
@@ -615,9 +778,9 @@ func (b *BCBP) setFieldByItem(s string, item item, leg int) (int, error) {
 	case documentFormSerialNumber:
 		b.Legs[leg].DocumentFormSerialNumber = val
 	case selecteeIndicator:
-		b.Legs[leg].SelecteeIndicator = val
+		b.Legs[leg].SelecteeIndicator = SelecteeIndicator(val)
 	case internationalDocumentationVerification:
-		b.Legs[leg].InternationalDocumentationVerification = val
+		b.Legs[leg].InternationalDocumentationVerification = InternationalDocumentationVerification(val)
 	case marketingCarrierDesignator:
 		b.Legs[leg].MarketingCarrierDesignator = val
 	case frequentFlyerAirlineDesignator:
@@ -625,11 +788,11 @@ func (b *BCBP) setFieldByItem(s string, item item, leg int) (int, error) {
 	case frequentFlyerNumber:
 		b.Legs[leg].FrequentFlyerNumber = val
 	case idadIndicator:
-		b.Legs[leg].IDADIndicator = val
+		b.Legs[leg].IDADIndicator = IDADIndicator(val)
 	case freeBaggageAllowance:
 		b.Legs[leg].FreeBaggageAllowance = val
 	case fastTrack:
-		b.Legs[leg].FastTrack = val
+		b.Legs[leg].FastTrack = FastTrack(val)
 	case forIndividualAirlineUse:
 		b.Legs[leg].ForIndividualAirlineUse = val
 	case typeOfSecurityData:
@@ -686,6 +849,12 @@ func (b *BCBP) setFieldByItem(s string, item item, leg int) (int, error) {
 			break
 		}
 
+		// Skip items that are not part of the layout of the version used
+		// to encode the Bar Coded Boarding Pass.
+		if !subItem.inLayout(b.layoutVersion) {
+			continue
+		}
+
 		subItemLen, err := b.setFieldByItem(sectionStr, subItem, leg)
 		if err != nil {
 			return subItemLen, err
@@ -700,5 +869,14 @@ func (b *BCBP) setFieldByItem(s string, item item, leg int) (int, error) {
 		sectionStr = sectionStr[subItemLen:]
 	}
 
+	// Structured messages may hold items introduced in a later version than
+	// the layout used. Their length allows these items to be skipped.
+	switch item.id {
+	case fieldSizeOfFollowingStructuredMessageUnique,
+		fieldSizeOfFollowingStructuredMessageRepeated:
+		itemLen += len(sectionStr)
+		b.pos += len(sectionStr)
+	}
+
 	return itemLen, nil
 }
@@ -2,11 +2,14 @@ package bcbp
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -15,6 +18,10 @@ import (
 // a new test input file is added or there is a change in logic.
 var update = flag.Bool("update", false, "update .golden files") //nolint
 
+// referenceTime is the time used to resolve the year of Julian dates so that
+// .golden files do not change over time.
+var referenceTime = time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC)
+
 func TestFromStr(t *testing.T) {
 	testFromStr(t, "testdata/*.input", false)
 }
@@ -23,7 +30,11 @@ func TestFromStr_Errors(t *testing.T) {
 	testFromStr(t, "testdata/errors/*.input", true)
 }
 
-func testFromStr(t *testing.T, in string, wantErr bool) {
+func TestFromStr_Lenient(t *testing.T) {
+	testFromStr(t, "testdata/lenient/*.input", true, WithLenientDecoding())
+}
+
+func testFromStr(t *testing.T, in string, wantErr bool, opts ...Option) {
 	t.Helper()
 
 	match, err := filepath.Glob(in)
@@ -49,7 +60,8 @@ func testFromStr(t *testing.T, in string, wantErr bool) {
 			}
 
 			var got []byte
-			b, err := FromStr(string(data))
+			opts := append([]Option{WithReferenceTime(referenceTime)}, opts...)
+			b, err := FromStrWithOptions(string(data), opts...)
 			switch {
 			case wantErr && err == nil:
 				t.Error("FromStr() = nil: expected error")
@@ -71,6 +83,94 @@ func testFromStr(t *testing.T, in string, wantErr bool) {
 	}
 }
 
+// TestFromStr_Versions checks the items of the version fixtures that depend
+// on the layout of their version.
+func TestFromStr_Versions(t *testing.T) {
+	for version := uint(1); version <= latestVersion; version++ {
+		in := fmt.Sprintf("testdata/version_%d_no_security_single.input", version)
+		t.Run(in, func(t *testing.T) {
+			data, err := os.ReadFile(in)
+			if err != nil {
+				t.Fatalf("failed reading .input file: %v", err)
+			}
+
+			b, err := FromStrWithOptions(string(data), WithReferenceTime(referenceTime))
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", data, err)
+			}
+
+			if b.VersionNumber != version || b.LayoutVersion() != version {
+				t.Errorf("VersionNumber, LayoutVersion() = %d, %d, want %d, %d", b.VersionNumber, b.LayoutVersion(), version, version)
+			}
+
+			// Fast Track was introduced in version 5.
+			var fastTrack FastTrack
+			if version >= 5 {
+				fastTrack = FastTrackYes
+			}
+			if got := b.Legs[0].FastTrack; got != fastTrack {
+				t.Errorf("FastTrack = %q, want %q", got, fastTrack)
+			}
+
+			// Every other item is decoded the same way by every version.
+			if got, want := b.DateOfIssueOfBoardingPass, Date(20211121); got != want {
+				t.Errorf("DateOfIssueOfBoardingPass = %v, want %v", got, want)
+			}
+			if got, want := b.Legs[0].FreeBaggageAllowance, "4PC"; got != want {
+				t.Errorf("FreeBaggageAllowance = %q, want %q", got, want)
+			}
+			if got, want := b.Legs[0].ForIndividualAirlineUse, "LX58Z"; got != want {
+				t.Errorf("ForIndividualAirlineUse = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestFromStr_LayoutVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            string
+		layoutVersion uint
+		fastTrack     FastTrack
+	}{
+		{
+			name: "mandatory",
+			in:   "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100",
+		},
+		{
+			name:          "version 5",
+			in:            "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 130>5002A0141234567890 1AC AC 1234567890123    20KY",
+			layoutVersion: 5,
+			fastTrack:     FastTrackYes,
+		},
+		{
+			// Fast Track was introduced in version 5 and is skipped.
+			name:          "version 4",
+			in:            "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 130>4002A0141234567890 1AC AC 1234567890123    20KY",
+			layoutVersion: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromStr(tt.in)
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", tt.in, err)
+			}
+
+			if got := b.LayoutVersion(); got != tt.layoutVersion {
+				t.Errorf("LayoutVersion() = %d, want %d", got, tt.layoutVersion)
+			}
+			if got := b.Legs[0].FastTrack; got != tt.fastTrack {
+				t.Errorf("FastTrack = %q, want %q", got, tt.fastTrack)
+			}
+			if got := b.Legs[0].ForIndividualAirlineUse; got != "" {
+				t.Errorf("ForIndividualAirlineUse = %q, want %q", got, "")
+			}
+		})
+	}
+}
+
 func runFromStrTest(t *testing.T, got []byte, in string) {
 	t.Helper()
 
@@ -128,3 +228,213 @@ func BenchmarkFromStr_Full_No_Security_Multi(b *testing.B) {
 func BenchmarkFromStr_Full_Multi(b *testing.B) {
 	benchmarkFromStr("testdata/full_multi.input", b)
 }
+
+func TestFromStr_Lenient_PartialResult(t *testing.T) {
+	const s = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326101AA0025 !00"
+	b, err := FromStrWithOptions(s, WithLenientDecoding())
+
+	var errs DecodeErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("FromStrWithOptions() = %v: expected DecodeErrors", err)
+	}
+	if len(errs) != 3 {
+		t.Errorf("len(DecodeErrors) = %d, want 3", len(errs))
+	}
+
+	var de *DecodeError
+	if !errors.As(err, &de) || de.Item != "Compartment Code" {
+		t.Errorf("errors.As() = %v: expected first *DecodeError to be for Compartment Code", de)
+	}
+
+	want := Leg{
+		OperatingCarrierPNRCode:    "ABC123",
+		FromCityAirportCode:        "YUL",
+		ToCityAirportCode:          "FRA",
+		OperatingCarrierDesignator: "AC",
+		FlightNumber:               "0834",
+		DateOfFlight:               b.Legs[0].DateOfFlight,
+		CheckInSequenceNumber:      "0025 ",
+	}
+	if diff := cmp.Diff(want, b.Legs[0]); diff != "" {
+		t.Errorf("leg mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFromStr_DateOfFlight_LeapDay(t *testing.T) {
+	const s = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 366J001A0025 100"
+
+	// 2020 is a leap year.
+	b, err := FromStrWithOptions(s, WithReferenceTime(referenceTime))
+	if err != nil {
+		t.Fatalf("FromStrWithOptions() returned unexpected error: %+v", err)
+	}
+	if got, want := b.Legs[0].DateOfFlight.String(), "2020-12-31"; got != want {
+		t.Errorf("DateOfFlight = %s, want %s", got, want)
+	}
+
+	// None of 2025, 2026, and 2027 are leap years.
+	ref := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	_, err = FromStrWithOptions(s, WithReferenceTime(ref))
+	var de *DecodeError
+	if !errors.As(err, &de) || de.Type != ErrInvalidDataFormat || de.Value != "366" {
+		t.Errorf("FromStrWithOptions() = %v: expected %v for Date of Flight", err, ErrInvalidDataFormat)
+	}
+
+	b, err = FromStrWithOptions(s, WithReferenceTime(ref), WithLenientDecoding())
+	if !errors.Is(err, ErrInvalidDataFormat) {
+		t.Errorf("FromStrWithOptions() = %v: expected %v", err, ErrInvalidDataFormat)
+	}
+	if !b.Legs[0].DateOfFlight.IsZero() {
+		t.Errorf("DateOfFlight = %s, want empty", b.Legs[0].DateOfFlight)
+	}
+}
+
+func TestDecodeInto(t *testing.T) {
+	match, err := filepath.Glob("testdata/*.input")
+	if err != nil {
+		t.Fatalf("failed to match input files: %v", err)
+	}
+
+	// dst is reused for every input.
+	var dst BCBP
+	for _, in := range match {
+		t.Run(filepath.Base(in), func(t *testing.T) {
+			data, err := os.ReadFile(in)
+			if err != nil {
+				t.Fatalf("failed reading .input file: %v", err)
+			}
+
+			want, err := FromStrWithOptions(string(data), WithReferenceTime(referenceTime))
+			if err != nil {
+				t.Fatalf("FromStrWithOptions() returned unexpected error: %+v", err)
+			}
+			wantJSON, _ := json.Marshal(want)
+
+			if err := DecodeInto(&dst, string(data), WithReferenceTime(referenceTime)); err != nil {
+				t.Fatalf("DecodeInto() returned unexpected error: %+v", err)
+			}
+			got, _ := json.Marshal(dst)
+			if diff := cmp.Diff(string(wantJSON), string(got)); diff != "" {
+				t.Errorf("DecodeInto() mismatch (-want +got):\n%s", diff)
+			}
+
+			if err := DecodeBytesInto(&dst, data, WithReferenceTime(referenceTime)); err != nil {
+				t.Fatalf("DecodeBytesInto() returned unexpected error: %+v", err)
+			}
+			got, _ = json.Marshal(dst)
+			if diff := cmp.Diff(string(wantJSON), string(got)); diff != "" {
+				t.Errorf("DecodeBytesInto() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeInto_Errors(t *testing.T) {
+	var dst BCBP
+	if err := DecodeInto(&dst, "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326101AA0025 100", WithLenientDecoding()); err == nil {
+		t.Fatal("DecodeInto() = nil: expected error")
+	}
+
+	// Previous results do not leak into the next decode.
+	if err := DecodeInto(&dst, "M1DESMARAIS/LUC"); err == nil {
+		t.Fatal("DecodeInto() = nil: expected error")
+	}
+	if dst.PassengerName != "" || dst.Legs[0] != (Leg{}) {
+		t.Errorf("DecodeInto() did not reset dst: %+v", dst)
+	}
+}
+
+func TestDecodeBytesInto_Copy(t *testing.T) {
+	data := []byte("M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100")
+
+	var dst BCBP
+	if err := DecodeBytesInto(&dst, data, WithCopy()); err != nil {
+		t.Fatalf("DecodeBytesInto() returned unexpected error: %+v", err)
+	}
+
+	// Modifying data does not modify dst.
+	copy(data[2:], "XXXXXXXXX")
+	if got, want := dst.PassengerName, PassengerName("DESMARAIS/LUC"); got != want {
+		t.Errorf("PassengerName = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeInto_Allocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not meaningful with the race detector")
+	}
+
+	match, err := filepath.Glob("testdata/*.input")
+	if err != nil {
+		t.Fatalf("failed to match input files: %v", err)
+	}
+
+	var dst BCBP
+	for _, in := range match {
+		data, err := os.ReadFile(in)
+		if err != nil {
+			t.Fatalf("failed reading .input file: %v", err)
+		}
+		s := string(data)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = DecodeInto(&dst, s)
+		})
+		if allocs != 0 {
+			t.Errorf("%s: DecodeInto() allocs = %v, want 0", filepath.Base(in), allocs)
+		}
+	}
+}
+
+func BenchmarkDecodeInto_Mandatory_No_Security_Single(b *testing.B) {
+	benchmarkDecodeInto("testdata/mandatory_no_security_single.input", b)
+}
+
+func BenchmarkDecodeInto_Mandatory_Single(b *testing.B) {
+	benchmarkDecodeInto("testdata/mandatory_single.input", b)
+}
+
+func BenchmarkDecodeInto_Full_No_Security_Single(b *testing.B) {
+	benchmarkDecodeInto("testdata/full_no_security_single.input", b)
+}
+
+func BenchmarkDecodeInto_Full_Single(b *testing.B) {
+	benchmarkDecodeInto("testdata/full_single.input", b)
+}
+
+func BenchmarkDecodeInto_Full_No_Security_Multi(b *testing.B) {
+	benchmarkDecodeInto("testdata/full_no_security_multi.input", b)
+}
+
+func BenchmarkDecodeInto_Full_Multi(b *testing.B) {
+	benchmarkDecodeInto("testdata/full_multi.input", b)
+}
+
+func BenchmarkDecodeBytesInto_Full_Multi(b *testing.B) {
+	data, err := os.ReadFile("testdata/full_multi.input")
+	if err != nil {
+		b.Errorf("failed reading .input file: %v", err)
+	}
+
+	var dst BCBP
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = DecodeBytesInto(&dst, data)
+	}
+}
+
+func benchmarkDecodeInto(in string, b *testing.B) {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		b.Errorf("failed reading .input file: %v", err)
+	}
+
+	s := string(data)
+	var dst BCBP
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = DecodeInto(&dst, s)
+	}
+}
@@ -0,0 +1,99 @@
+package bcbp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckInSequenceNumber is the order in which a passenger has checked-in for
+// a flight. It is 4 digits with leading zeroes followed by an optional alpha
+// suffix, e.g. 0025 or 0025A.
+//
+// A whitespace suffix is the same as no suffix.
+type CheckInSequenceNumber string
+
+// maxCheckInSequenceNumber is the highest number that can be encoded in a
+// CheckInSequenceNumber.
+const maxCheckInSequenceNumber = 9999
+
+// NewCheckInSequenceNumber returns the CheckInSequenceNumber of number and
+// suffix with leading zeroes, e.g. NewCheckInSequenceNumber(25, 'A') returns
+// 0025A. suffix is 0 if the check-in sequence number does not have a suffix.
+// An error is returned if number is not between 0 and 9999 or suffix is not
+// an alpha.
+func NewCheckInSequenceNumber(number int, suffix byte) (CheckInSequenceNumber, error) {
+	if number < 0 || number > maxCheckInSequenceNumber {
+		return "", fmt.Errorf("bcbp: check-in sequence number %d must be between 0 and %d", number, maxCheckInSequenceNumber)
+	}
+	if suffix == 0 {
+		return CheckInSequenceNumber(fmt.Sprintf("%04d", number)), nil
+	}
+	if !isAlpha(suffix) {
+		return "", fmt.Errorf("bcbp: check-in sequence number suffix %q must be an alpha", suffix)
+	}
+	return CheckInSequenceNumber(fmt.Sprintf("%04d%c", number, upper(suffix))), nil
+}
+
+// parse returns the number and the suffix of c. ok is false if c is not 4
+// digits followed by an optional alpha or whitespace.
+func (c CheckInSequenceNumber) parse() (number int, suffix byte, ok bool) {
+	s := strings.TrimRight(string(c), " ")
+	if len(s) == 5 && isAlpha(s[4]) {
+		suffix = s[4]
+		s = s[:4]
+	}
+	if len(s) != 4 {
+		return 0, 0, false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, 0, false
+		}
+		number = number*10 + int(s[i]-'0')
+	}
+	return number, suffix, true
+}
+
+// Valid reports whether c is 4 digits followed by an optional alpha or
+// whitespace.
+func (c CheckInSequenceNumber) Valid() bool {
+	_, _, ok := c.parse()
+	return ok
+}
+
+// Number returns the numeric part of c. 0 is returned if c is not Valid.
+func (c CheckInSequenceNumber) Number() int {
+	number, _, _ := c.parse()
+	return number
+}
+
+// Suffix returns the suffix of c as it is encoded. 0 is returned if c does not
+// have a suffix or is not Valid.
+func (c CheckInSequenceNumber) Suffix() byte {
+	_, suffix, _ := c.parse()
+	return suffix
+}
+
+// Compare returns -1, 0, or +1 depending on whether c sorts before, the same
+// as, or after o.
+//
+// Check-in sequence numbers sort first by number and then by suffix, where
+// no suffix sorts before any suffix. Suffixes are compared ignoring case.
+// Invalid check-in sequence numbers sort last, ordered lexically.
+func (c CheckInSequenceNumber) Compare(o CheckInSequenceNumber) int {
+	n1, s1, ok1 := c.parse()
+	n2, s2, ok2 := o.parse()
+	switch {
+	case ok1 && !ok2:
+		return -1
+	case !ok1 && ok2:
+		return 1
+	case !ok1 && !ok2:
+		return strings.Compare(string(c), string(o))
+	}
+
+	if cmp := compareInt(n1, n2); cmp != 0 {
+		return cmp
+	}
+	return compareInt(int(upper(s1)), int(upper(s2)))
+}
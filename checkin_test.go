@@ -0,0 +1,108 @@
+package bcbp
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckInSequenceNumber(t *testing.T) {
+	tests := []struct {
+		c      CheckInSequenceNumber
+		valid  bool
+		number int
+		suffix byte
+	}{
+		{c: "0025", valid: true, number: 25},
+		{c: "0025 ", valid: true, number: 25},
+		{c: "0025A", valid: true, number: 25, suffix: 'A'},
+		{c: "0025b", valid: true, number: 25, suffix: 'b'},
+		{c: "025A"},
+		{c: "0025AB"},
+		{c: "00X5"},
+		{c: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.c), func(t *testing.T) {
+			if got := tt.c.Valid(); got != tt.valid {
+				t.Errorf("Valid() = %t, want %t", got, tt.valid)
+			}
+			if got := tt.c.Number(); got != tt.number {
+				t.Errorf("Number() = %d, want %d", got, tt.number)
+			}
+			if got := tt.c.Suffix(); got != tt.suffix {
+				t.Errorf("Suffix() = %q, want %q", got, tt.suffix)
+			}
+		})
+	}
+}
+
+func TestNewCheckInSequenceNumber(t *testing.T) {
+	tests := []struct {
+		number  int
+		suffix  byte
+		want    CheckInSequenceNumber
+		wantErr bool
+	}{
+		{number: 25, want: "0025"},
+		{number: 25, suffix: 'b', want: "0025B"},
+		{number: 10000, wantErr: true},
+		{number: -1, wantErr: true},
+		{number: 25, suffix: ' ', wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NewCheckInSequenceNumber(tt.number, tt.suffix)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("NewCheckInSequenceNumber(%d, %q) = %q: expected error", tt.number, tt.suffix, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewCheckInSequenceNumber(%d, %q) returned unexpected error: %+v", tt.number, tt.suffix, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NewCheckInSequenceNumber(%d, %q) = %q, want %q", tt.number, tt.suffix, got, tt.want)
+		}
+	}
+}
+
+func TestCheckInSequenceNumber_Compare(t *testing.T) {
+	numbers := []CheckInSequenceNumber{"0100", "X", "0025b", "0025", "0025A", "0003 "}
+	sort.SliceStable(numbers, func(i, j int) bool {
+		return numbers[i].Compare(numbers[j]) < 0
+	})
+
+	want := []CheckInSequenceNumber{"0003 ", "0025", "0025A", "0025b", "0100", "X"}
+	if diff := cmp.Diff(want, numbers); diff != "" {
+		t.Errorf("Compare() order mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFromStr_CheckInSequenceNumberSuffix(t *testing.T) {
+	for _, c := range []CheckInSequenceNumber{"0025 ", "0025A", "0025b"} {
+		t.Run(string(c), func(t *testing.T) {
+			s := "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A" + fmt.Sprintf("%-5s", c) + "100"
+			b, err := FromStr(s)
+			if err != nil {
+				t.Fatalf("FromStr() returned unexpected error: %+v", err)
+			}
+			if got := b.Legs[0].CheckInSequenceNumber; got != c {
+				t.Errorf("CheckInSequenceNumber = %q, want %q", got, c)
+			}
+
+			got, err := b.Encode()
+			if err != nil {
+				t.Fatalf("Encode() returned unexpected error: %+v", err)
+			}
+			if got != s {
+				t.Errorf("Encode() = %q, want %q", got, s)
+			}
+		})
+	}
+}
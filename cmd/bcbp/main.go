@@ -0,0 +1,149 @@
+// Command bcbp decodes IATA 792 Bar Coded Boarding Pass data.
+//
+// Usage:
+//   bcbp [flags] [file ...]
+//
+// Each line of the given files, or standard input if no files are given, is
+// decoded as a Bar Coded Boarding Pass. Decoded boarding passes are printed to
+// standard output either as one JSON object per line or as a table. Errors are
+// printed to standard error and bcbp exits with a non-zero status if any line
+// fails to decode.
+//
+// The flags are:
+//   -format string
+//       output format, either json or table (default "json")
+//   -lenient
+//       continue decoding past invalid items
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jandauz/boarding-pass"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs bcbp with args and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("bcbp", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "json", "output format, either json or table")
+	lenient := fs.Bool("lenient", false, "continue decoding past invalid items")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *format != "json" && *format != "table" {
+		fmt.Fprintf(stderr, "bcbp: unknown format %q\n", *format)
+		return 2
+	}
+
+	var opts []bcbp.Option
+	if *lenient {
+		opts = append(opts, bcbp.WithLenientDecoding())
+	}
+
+	d := decoder{
+		format: *format,
+		opts:   opts,
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	if fs.NArg() == 0 {
+		d.decode("<stdin>", stdin)
+		return d.status()
+	}
+
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintf(stderr, "bcbp: %v\n", err)
+			d.failed = true
+			continue
+		}
+		d.decode(name, f)
+		f.Close()
+	}
+	return d.status()
+}
+
+// decoder decodes every line of its input as a Bar Coded Boarding Pass.
+type decoder struct {
+	format string
+	opts   []bcbp.Option
+	stdout io.Writer
+	stderr io.Writer
+
+	// failed is set if any line failed to decode.
+	failed bool
+}
+
+// status returns the exit status of bcbp.
+func (d *decoder) status() int {
+	if d.failed {
+		return 1
+	}
+	return 0
+}
+
+// decode decodes each line of r. name is used to report errors.
+func (d *decoder) decode(name string, r io.Reader) {
+	dec := bcbp.NewDecoder(r, bcbp.WithDecodeOptions(d.opts...))
+	for dec.Next(context.Background()) {
+		res := dec.Result()
+		if res.Err != nil {
+			fmt.Fprintf(d.stderr, "%s:%d:\n%v\n", name, res.Line, res.Err)
+			d.failed = true
+
+			// In lenient mode, print what could be decoded.
+			if _, ok := res.Err.(bcbp.DecodeErrors); !ok {
+				continue
+			}
+		}
+
+		if err := d.print(res.BCBP); err != nil {
+			fmt.Fprintf(d.stderr, "%s:%d: %v\n", name, res.Line, err)
+			d.failed = true
+		}
+	}
+
+	if err := dec.Err(); err != nil {
+		fmt.Fprintf(d.stderr, "bcbp: %s: %v\n", name, err)
+		d.failed = true
+	}
+}
+
+// print prints b to stdout using the format of d.
+func (d *decoder) print(b bcbp.BCBP) error {
+	if d.format == "json" {
+		data, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(d.stdout, "%s\n", data)
+		return err
+	}
+
+	fields, err := b.Fields()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(d.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LEG\tOFFSET\tITEM\tVALUE")
+	for _, f := range fields {
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%q\n", f.Leg+1, f.Offset, f.Description, f.Raw)
+	}
+	fmt.Fprintln(tw)
+	return tw.Flush()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const (
+	valid   = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100"
+	invalid = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 3261001A0025 100"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantStatus int
+		wantStdout []string
+		wantStderr []string
+	}{
+		{
+			name:       "json",
+			stdin:      valid + "\n\n" + valid + "\r\n",
+			wantStdout: []string{`"passenger_name":"DESMARAIS/LUC"`},
+		},
+		{
+			name:       "table",
+			args:       []string{"-format", "table"},
+			stdin:      valid,
+			wantStdout: []string{"Passenger Name", `"DESMARAIS/LUC       "`},
+		},
+		{
+			name:       "invalid line",
+			stdin:      valid + "\n" + invalid,
+			wantStatus: 1,
+			wantStdout: []string{`"passenger_name":"DESMARAIS/LUC"`},
+			wantStderr: []string{"<stdin>:2:", "Compartment Code"},
+		},
+		{
+			name:       "lenient",
+			args:       []string{"-lenient"},
+			stdin:      invalid,
+			wantStatus: 1,
+			wantStdout: []string{`"seat_number":"001A"`},
+			wantStderr: []string{"<stdin>:1:", "Compartment Code"},
+		},
+		{
+			name:       "file",
+			args:       []string{"../../testdata/full_multi.input"},
+			wantStdout: []string{`"to_city_airport_code":"GVA"`},
+		},
+		{
+			name:       "missing file",
+			args:       []string{"missing.input"},
+			wantStatus: 1,
+			wantStderr: []string{"missing.input"},
+		},
+		{
+			name:       "unknown format",
+			args:       []string{"-format", "xml"},
+			wantStatus: 2,
+			wantStderr: []string{`unknown format "xml"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if status != tt.wantStatus {
+				t.Errorf("run() = %d, want %d; stderr:\n%s", status, tt.wantStatus, stderr.String())
+			}
+
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("stdout does not contain %q:\n%s", want, stdout.String())
+				}
+			}
+			for _, want := range tt.wantStderr {
+				if !strings.Contains(stderr.String(), want) {
+					t.Errorf("stderr does not contain %q:\n%s", want, stderr.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package bcbp
+
+import "encoding/json"
+
+// ElectronicTicketIndicator is a flag that indicates whether or not the
+// boarding pass is issued against an electronic ticket.
+type ElectronicTicketIndicator string
+
+const (
+	ElectronicTicketIndicatorElectronicTicket ElectronicTicketIndicator = "E"
+	ElectronicTicketIndicatorTicketless       ElectronicTicketIndicator = "L"
+)
+
+// String returns the description of the electronic ticket indicator.
+func (e ElectronicTicketIndicator) String() string {
+	switch e {
+	case ElectronicTicketIndicatorElectronicTicket:
+		return "Electronic ticket"
+	case ElectronicTicketIndicatorTicketless:
+		return "Ticketless"
+	}
+	return unknownCode(string(e))
+}
+
+// PassengerDescription is the description of the passenger.
+type PassengerDescription string
+
+const (
+	PassengerDescriptionAdult                    PassengerDescription = "0"
+	PassengerDescriptionMale                     PassengerDescription = "1"
+	PassengerDescriptionFemale                   PassengerDescription = "2"
+	PassengerDescriptionChild                    PassengerDescription = "3"
+	PassengerDescriptionInfant                   PassengerDescription = "4"
+	PassengerDescriptionNoPassenger              PassengerDescription = "5"
+	PassengerDescriptionAdultTravelingWithInfant PassengerDescription = "6"
+	PassengerDescriptionUnaccompaniedMinor       PassengerDescription = "7"
+	PassengerDescriptionUnspecified              PassengerDescription = "X"
+	PassengerDescriptionUndisclosed              PassengerDescription = "U"
+)
+
+// String returns the description of the passenger description.
+func (p PassengerDescription) String() string {
+	switch p {
+	case PassengerDescriptionAdult:
+		return "Adult"
+	case PassengerDescriptionMale:
+		return "Male"
+	case PassengerDescriptionFemale:
+		return "Female"
+	case PassengerDescriptionChild:
+		return "Child"
+	case PassengerDescriptionInfant:
+		return "Infant"
+	case PassengerDescriptionNoPassenger:
+		return "No passenger (cabin baggage)"
+	case PassengerDescriptionAdultTravelingWithInfant:
+		return "Adult traveling with infant"
+	case PassengerDescriptionUnaccompaniedMinor:
+		return "Unaccompanied minor"
+	case PassengerDescriptionUnspecified:
+		return "Unspecified"
+	case PassengerDescriptionUndisclosed:
+		return "Undisclosed"
+	}
+	return unknownCode(string(p))
+}
+
+// SourceOfCheckIn is where the check-in was initiated.
+type SourceOfCheckIn string
+
+const (
+	SourceOfCheckInWeb              SourceOfCheckIn = "W"
+	SourceOfCheckInAirportKiosk     SourceOfCheckIn = "K"
+	SourceOfCheckInTransferKiosk    SourceOfCheckIn = "X"
+	SourceOfCheckInRemoteKiosk      SourceOfCheckIn = "R"
+	SourceOfCheckInMobileDevice     SourceOfCheckIn = "M"
+	SourceOfCheckInAirportAgent     SourceOfCheckIn = "O"
+	SourceOfCheckInTownAgent        SourceOfCheckIn = "T"
+	SourceOfCheckInThirdPartyVendor SourceOfCheckIn = "V"
+	SourceOfCheckInAutomatedCheckIn SourceOfCheckIn = "A"
+)
+
+// String returns the description of the source of check-in.
+func (s SourceOfCheckIn) String() string {
+	switch s {
+	case SourceOfCheckInWeb:
+		return "Web"
+	case SourceOfCheckInAirportKiosk:
+		return "Airport kiosk"
+	case SourceOfCheckInTransferKiosk:
+		return "Transfer kiosk"
+	case SourceOfCheckInRemoteKiosk:
+		return "Remote or off site kiosk"
+	case SourceOfCheckInMobileDevice:
+		return "Mobile device"
+	case SourceOfCheckInAirportAgent:
+		return "Airport agent"
+	case SourceOfCheckInTownAgent:
+		return "Town agent"
+	case SourceOfCheckInThirdPartyVendor:
+		return "Third party vendor"
+	case SourceOfCheckInAutomatedCheckIn:
+		return "Automated check-in"
+	}
+	return unknownCode(string(s))
+}
+
+// SourceOfBoardingPassIssuance is where the boarding pass was issued.
+type SourceOfBoardingPassIssuance string
+
+const (
+	SourceOfBoardingPassIssuanceWeb              SourceOfBoardingPassIssuance = "W"
+	SourceOfBoardingPassIssuanceAirportKiosk     SourceOfBoardingPassIssuance = "K"
+	SourceOfBoardingPassIssuanceTransferKiosk    SourceOfBoardingPassIssuance = "X"
+	SourceOfBoardingPassIssuanceRemoteKiosk      SourceOfBoardingPassIssuance = "R"
+	SourceOfBoardingPassIssuanceMobileDevice     SourceOfBoardingPassIssuance = "M"
+	SourceOfBoardingPassIssuanceAirportAgent     SourceOfBoardingPassIssuance = "O"
+	SourceOfBoardingPassIssuanceTownAgent        SourceOfBoardingPassIssuance = "T"
+	SourceOfBoardingPassIssuanceThirdPartyVendor SourceOfBoardingPassIssuance = "V"
+)
+
+// String returns the description of the source of boarding pass issuance.
+func (s SourceOfBoardingPassIssuance) String() string {
+	switch s {
+	case SourceOfBoardingPassIssuanceWeb:
+		return "Web printed"
+	case SourceOfBoardingPassIssuanceAirportKiosk:
+		return "Airport kiosk printed"
+	case SourceOfBoardingPassIssuanceTransferKiosk:
+		return "Transfer kiosk printed"
+	case SourceOfBoardingPassIssuanceRemoteKiosk:
+		return "Remote or off site kiosk printed"
+	case SourceOfBoardingPassIssuanceMobileDevice:
+		return "Mobile device printed"
+	case SourceOfBoardingPassIssuanceAirportAgent:
+		return "Airport agent printed"
+	case SourceOfBoardingPassIssuanceTownAgent:
+		return "Town agent printed"
+	case SourceOfBoardingPassIssuanceThirdPartyVendor:
+		return "Third party vendor printed"
+	}
+	return unknownCode(string(s))
+}
+
+// DocumentType is the type of travel document provided.
+type DocumentType string
+
+const (
+	DocumentTypeBoardingPass     DocumentType = "B"
+	DocumentTypeItineraryReceipt DocumentType = "I"
+)
+
+// String returns the description of the document type.
+func (d DocumentType) String() string {
+	switch d {
+	case DocumentTypeBoardingPass:
+		return "Boarding pass"
+	case DocumentTypeItineraryReceipt:
+		return "Itinerary receipt"
+	}
+	return unknownCode(string(d))
+}
+
+// SelecteeIndicator is a flag that is used to classify passengers that
+// require additional screening.
+type SelecteeIndicator string
+
+const (
+	SelecteeIndicatorNotSelectee    SelecteeIndicator = "0"
+	SelecteeIndicatorSelectee       SelecteeIndicator = "1"
+	SelecteeIndicatorKnownPassenger SelecteeIndicator = "2"
+)
+
+// String returns the description of the selectee indicator.
+func (s SelecteeIndicator) String() string {
+	switch s {
+	case SelecteeIndicatorNotSelectee:
+		return "Not selectee"
+	case SelecteeIndicatorSelectee:
+		return "Selectee"
+	case SelecteeIndicatorKnownPassenger:
+		return "Known passenger"
+	}
+	return unknownCode(string(s))
+}
+
+// InternationalDocumentationVerification is a flag that is used to identify
+// passengers requiring their travel documentation to be verified.
+type InternationalDocumentationVerification string
+
+const (
+	InternationalDocumentationVerificationRequired    InternationalDocumentationVerification = "0"
+	InternationalDocumentationVerificationNotRequired InternationalDocumentationVerification = "1"
+	InternationalDocumentationVerificationPerformed   InternationalDocumentationVerification = "2"
+)
+
+// String returns the description of the international documentation
+// verification.
+func (i InternationalDocumentationVerification) String() string {
+	switch i {
+	case InternationalDocumentationVerificationRequired:
+		return "Travel documentation verification required"
+	case InternationalDocumentationVerificationNotRequired:
+		return "Travel documentation verification not required"
+	case InternationalDocumentationVerificationPerformed:
+		return "Travel documentation verification performed"
+	}
+	return unknownCode(string(i))
+}
+
+// IDADIndicator is a flag that specifies an industry discount ticket or
+// agency discount code.
+type IDADIndicator string
+
+const (
+	IDADIndicatorIDN1PositiveSpace  IDADIndicator = "0"
+	IDADIndicatorIDN2SpaceAvailable IDADIndicator = "1"
+	IDADIndicatorIDB1PositiveSpace  IDADIndicator = "2"
+	IDADIndicatorIDB2SpaceAvailable IDADIndicator = "3"
+	IDADIndicatorAD                 IDADIndicator = "4"
+	IDADIndicatorDG                 IDADIndicator = "5"
+	IDADIndicatorDM                 IDADIndicator = "6"
+	IDADIndicatorGE                 IDADIndicator = "7"
+	IDADIndicatorIG                 IDADIndicator = "8"
+	IDADIndicatorRG                 IDADIndicator = "9"
+	IDADIndicatorUD                 IDADIndicator = "A"
+	IDADIndicatorID                 IDADIndicator = "B"
+	IDADIndicatorIDFS1              IDADIndicator = "C"
+	IDADIndicatorIDFS2              IDADIndicator = "D"
+	IDADIndicatorIDR1               IDADIndicator = "E"
+)
+
+// String returns the description of the ID/AD indicator.
+func (i IDADIndicator) String() string {
+	switch i {
+	case IDADIndicatorIDN1PositiveSpace:
+		return "IDN1 positive space"
+	case IDADIndicatorIDN2SpaceAvailable:
+		return "IDN2 space available"
+	case IDADIndicatorIDB1PositiveSpace:
+		return "IDB1 positive space"
+	case IDADIndicatorIDB2SpaceAvailable:
+		return "IDB2 space available"
+	case IDADIndicatorAD:
+		return "AD"
+	case IDADIndicatorDG:
+		return "DG"
+	case IDADIndicatorDM:
+		return "DM"
+	case IDADIndicatorGE:
+		return "GE"
+	case IDADIndicatorIG:
+		return "IG"
+	case IDADIndicatorRG:
+		return "RG"
+	case IDADIndicatorUD:
+		return "UD"
+	case IDADIndicatorID:
+		return "ID"
+	case IDADIndicatorIDFS1:
+		return "IDFS1"
+	case IDADIndicatorIDFS2:
+		return "IDFS2"
+	case IDADIndicatorIDR1:
+		return "IDR1"
+	}
+	return unknownCode(string(i))
+}
+
+// FastTrack is a flag that specifies if the passenger is entitled to use a
+// priority, security, or immigration lane.
+type FastTrack string
+
+const (
+	FastTrackYes FastTrack = "Y"
+	FastTrackNo  FastTrack = "N"
+)
+
+// String returns the description of the fast track flag.
+func (f FastTrack) String() string {
+	switch f {
+	case FastTrackYes:
+		return "Yes"
+	case FastTrackNo:
+		return "No"
+	}
+	return unknownCode(string(f))
+}
+
+// unknownCode returns the description of a code that is not recognized.
+// An empty code is one that was left blank and has no description.
+func unknownCode(code string) string {
+	if code == "" {
+		return ""
+	}
+	return "Reserved for future industry use"
+}
+
+// describedCode is the JSON representation of a coded field when marshalled
+// using MarshalJSONWithDescriptions.
+type describedCode struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// describe returns the describedCode of code and its description. nil is
+// returned if code is empty so that it is omitted like the field it replaces.
+func describe(code, description string) *describedCode {
+	if code == "" {
+		return nil
+	}
+	return &describedCode{Code: code, Description: description}
+}
+
+// describedBCBP is the JSON representation of a BCBP when marshalled using
+// MarshalJSONWithDescriptions. Its fields have the same json names as the
+// coded fields of BCBP, which they take precedence over, while every other
+// field of BCBP is marshalled as usual.
+type describedBCBP struct {
+	BCBP
+	ElectronicTicketIndicator    describedCode  `json:"electronic_ticket_indicator"`
+	PassengerDescription         *describedCode `json:"passenger_description,omitempty"`
+	SourceOfCheckIn              *describedCode `json:"source_of_check_in,omitempty"`
+	SourceOfBoardingPassIssuance *describedCode `json:"source_of_boarding_pass_issuance,omitempty"`
+	DocumentType                 *describedCode `json:"document_type,omitempty"`
+	Legs                         []describedLeg `json:"legs"`
+}
+
+// describedLeg is the JSON representation of a Leg when marshalled using
+// MarshalJSONWithDescriptions. See describedBCBP.
+type describedLeg struct {
+	Leg
+	SelecteeIndicator                      *describedCode `json:"selectee_indicator,omitempty"`
+	InternationalDocumentationVerification *describedCode `json:"international_documentation_verification,omitempty"`
+	IDADIndicator                          *describedCode `json:"idad_indicator,omitempty"`
+	FastTrack                              *describedCode `json:"fast_track,omitempty"`
+}
+
+// MarshalJSONWithDescriptions returns the JSON encoding of b similar to
+// json.Marshal, except that coded fields such as PassengerDescription are
+// encoded as an object holding both the code and its description:
+//
+//	"passenger_description": {"code": "1", "description": "Male"}
+func (b BCBP) MarshalJSONWithDescriptions() ([]byte, error) {
+	d := describedBCBP{
+		BCBP: b,
+		ElectronicTicketIndicator: describedCode{
+			Code:        string(b.ElectronicTicketIndicator),
+			Description: b.ElectronicTicketIndicator.String(),
+		},
+		PassengerDescription:         describe(string(b.PassengerDescription), b.PassengerDescription.String()),
+		SourceOfCheckIn:              describe(string(b.SourceOfCheckIn), b.SourceOfCheckIn.String()),
+		SourceOfBoardingPassIssuance: describe(string(b.SourceOfBoardingPassIssuance), b.SourceOfBoardingPassIssuance.String()),
+		DocumentType:                 describe(string(b.DocumentType), b.DocumentType.String()),
+		Legs:                         make([]describedLeg, 0, len(b.Legs)),
+	}
+
+	// Similar to Legs.MarshalJSON, empty legs are omitted.
+	for _, l := range b.Legs {
+		if l == (Leg{}) {
+			continue
+		}
+		d.Legs = append(d.Legs, describedLeg{
+			Leg:                                    l,
+			SelecteeIndicator:                      describe(string(l.SelecteeIndicator), l.SelecteeIndicator.String()),
+			InternationalDocumentationVerification: describe(string(l.InternationalDocumentationVerification), l.InternationalDocumentationVerification.String()),
+			IDADIndicator:                          describe(string(l.IDADIndicator), l.IDADIndicator.String()),
+			FastTrack:                              describe(string(l.FastTrack), l.FastTrack.String()),
+		})
+	}
+	return json.Marshal(d)
+}
@@ -0,0 +1,153 @@
+package bcbp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCode_String(t *testing.T) {
+	tests := []struct {
+		code fmt.Stringer
+		want string
+	}{
+		{ElectronicTicketIndicatorElectronicTicket, "Electronic ticket"},
+		{PassengerDescriptionAdultTravelingWithInfant, "Adult traveling with infant"},
+		{PassengerDescription("Y"), "Reserved for future industry use"},
+		{PassengerDescription(""), ""},
+		{SourceOfCheckInAirportKiosk, "Airport kiosk"},
+		{SourceOfBoardingPassIssuanceWeb, "Web printed"},
+		{DocumentTypeItineraryReceipt, "Itinerary receipt"},
+		{SelecteeIndicatorKnownPassenger, "Known passenger"},
+		{InternationalDocumentationVerificationPerformed, "Travel documentation verification performed"},
+		{IDADIndicatorIDFS1, "IDFS1"},
+		{FastTrackNo, "No"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.code.String(); got != tt.want {
+			t.Errorf("%T(%q).String() = %q, want %q", tt.code, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestBCBP_MarshalJSONWithDescriptions(t *testing.T) {
+	data, err := os.ReadFile("testdata/full_multi.input")
+	if err != nil {
+		t.Fatalf("failed reading .input file: %v", err)
+	}
+
+	b, err := FromStrWithOptions(string(data), WithReferenceTime(referenceTime))
+	if err != nil {
+		t.Fatalf("FromStr(%s) returned unexpected error: %+v", data, err)
+	}
+
+	got, err := b.MarshalJSONWithDescriptions()
+	if err != nil {
+		t.Fatalf("MarshalJSONWithDescriptions() returned unexpected error: %+v", err)
+	}
+
+	var described map[string]interface{}
+	if err := json.Unmarshal(got, &described); err != nil {
+		t.Fatalf("json.Unmarshal() returned unexpected error: %+v", err)
+	}
+
+	want := map[string]interface{}{
+		"code":        "W",
+		"description": "Web",
+	}
+	if diff := cmp.Diff(want, described["source_of_check_in"]); diff != "" {
+		t.Errorf("source_of_check_in mismatch (-want +got):\n%s", diff)
+	}
+
+	legs := described["legs"].([]interface{})
+	want = map[string]interface{}{
+		"code":        "N",
+		"description": "No",
+	}
+	if diff := cmp.Diff(want, legs[1].(map[string]interface{})["fast_track"]); diff != "" {
+		t.Errorf("fast_track mismatch (-want +got):\n%s", diff)
+	}
+
+	// Replacing the described codes with their code should produce the
+	// same output as json.Marshal.
+	undescribe(described)
+	for _, leg := range legs {
+		undescribe(leg.(map[string]interface{}))
+	}
+
+	plain, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned unexpected error: %+v", err)
+	}
+	var wantPlain map[string]interface{}
+	if err := json.Unmarshal(plain, &wantPlain); err != nil {
+		t.Fatalf("json.Unmarshal() returned unexpected error: %+v", err)
+	}
+
+	if diff := cmp.Diff(wantPlain, described); diff != "" {
+		t.Errorf("output mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestBCBP_MarshalJSONWithDescriptions_Plain checks that every fixture,
+// including those with empty codes, is marshalled like json.Marshal once the
+// described codes are replaced with their code.
+func TestBCBP_MarshalJSONWithDescriptions_Plain(t *testing.T) {
+	match, err := filepath.Glob("testdata/*.input")
+	if err != nil {
+		t.Fatalf("failed to find .input files: %v", err)
+	}
+
+	for _, in := range match {
+		t.Run(in, func(t *testing.T) {
+			data, err := os.ReadFile(in)
+			if err != nil {
+				t.Fatalf("failed reading .input file: %v", err)
+			}
+			b, err := FromStrWithOptions(string(data), WithReferenceTime(referenceTime))
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", data, err)
+			}
+
+			described, err := b.MarshalJSONWithDescriptions()
+			if err != nil {
+				t.Fatalf("MarshalJSONWithDescriptions() returned unexpected error: %+v", err)
+			}
+			var got map[string]interface{}
+			if err := json.Unmarshal(described, &got); err != nil {
+				t.Fatalf("json.Unmarshal() returned unexpected error: %+v", err)
+			}
+			undescribe(got)
+			for _, leg := range got["legs"].([]interface{}) {
+				undescribe(leg.(map[string]interface{}))
+			}
+
+			plain, err := json.Marshal(b)
+			if err != nil {
+				t.Fatalf("json.Marshal() returned unexpected error: %+v", err)
+			}
+			var want map[string]interface{}
+			if err := json.Unmarshal(plain, &want); err != nil {
+				t.Fatalf("json.Unmarshal() returned unexpected error: %+v", err)
+			}
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("output mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// undescribe replaces described codes in m with their code.
+func undescribe(m map[string]interface{}) {
+	for k, v := range m {
+		if d, ok := v.(map[string]interface{}); ok {
+			m[k] = d["code"]
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package bcbp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// dateLayout is the layout of a RFC 3339 full-date.
+const dateLayout = "2006-01-02"
+
+// Date is a date formatted as a RFC 3339 full-date, e.g. 2021-11-22. The zero
+// Date is empty.
+//
+// Bar Coded Boarding Passes encode dates as Julian Dates. These are resolved
+// to a full date when decoding. See WithReferenceTime.
+//
+// A Date holds the year, month, and day as the decimal number yyyymmdd so
+// that decoding a date does not allocate. Two Dates are therefore equal if
+// and only if they are the same day.
+//
+// Resolving a Julian Date keeps the last digit of the year and the day of
+// the year, so JulianDate of a decoded Date returns the Julian Date it was
+// decoded from. The encoded characters themselves are kept by the BCBP and
+// are returned by BCBP.Fields.
+type Date uint64
+
+// DateOf returns the date of t in the location of t. The zero Date is
+// returned if the year of t is not between 1 and 9999.
+func DateOf(t time.Time) Date {
+	if t.Year() < 1 || t.Year() > 9999 {
+		return 0
+	}
+	return Date(t.Year()*10000 + int(t.Month())*100 + t.Day())
+}
+
+// ParseDate parses a RFC 3339 full-date, e.g. 2021-11-22. An empty string is
+// parsed as the zero Date.
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return 0, fmt.Errorf("bcbp: date %q must be formatted as %s: %w", s, dateLayout, err)
+	}
+	return DateOf(t), nil
+}
+
+// IsZero reports whether d is empty.
+func (d Date) IsZero() bool {
+	return d == 0
+}
+
+// Time returns d as a time.Time at midnight UTC. An error is returned if d is
+// empty or is not a valid date.
+func (d Date) Time() (time.Time, error) {
+	if d.IsZero() {
+		return time.Time{}, errors.New("bcbp: date is empty")
+	}
+	year, month, day := int(d/10000), time.Month(d/100%100), int(d%100)
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if t.Year() != year || t.Month() != month || t.Day() != day {
+		return time.Time{}, fmt.Errorf("bcbp: date %d is not valid", uint64(d))
+	}
+	return t, nil
+}
+
+// JulianDate returns d as it is encoded in a Bar Coded Boarding Pass. That is,
+// the last digit of the year and the day of the year starting at 1 for
+// January 1. An error is returned if d is empty or is not a valid date.
+func (d Date) JulianDate() (yearDigit, day int, err error) {
+	t, err := d.Time()
+	if err != nil {
+		return 0, 0, err
+	}
+	return t.Year() % 10, t.YearDay(), nil
+}
+
+// String returns d formatted as a RFC 3339 full-date. It returns an empty
+// string if d is empty or is not a valid date.
+func (d Date) String() string {
+	t, err := d.Time()
+	if err != nil {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+// MarshalText implements encoding.TextMarshaler. d is formatted as a RFC 3339
+// full-date.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. text must be a RFC 3339
+// full-date or empty.
+func (d *Date) UnmarshalText(text []byte) error {
+	date, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
+
+// julianDate returns the date of the Julian day closest to ref. Only years
+// that are within step years of ref and that end in the same digits as year
+// modulo step are considered. ok is false if day does not exist in any of
+// these years, e.g. day 366 when none of them are leap years.
+//
+// For example, with a step of 1 every year is considered. With a step of 10
+// only years ending in year are considered.
+func julianDate(ref time.Time, year, step, day int) (t time.Time, ok bool) {
+	ref = time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Find the year closest to ref ending in the same digits as year.
+	y := ref.Year() - ((ref.Year()-year)%step+step)%step
+
+	for _, candidate := range []int{y - step, y, y + step} {
+		c := time.Date(candidate, time.January, 0, 0, 0, 0, 0, time.UTC)
+		c = c.AddDate(0, 0, day)
+
+		// Day 366 only exists in leap years.
+		if c.Year() != candidate {
+			continue
+		}
+
+		if !ok || abs(c.Sub(ref)) < abs(t.Sub(ref)) {
+			t, ok = c, true
+		}
+	}
+	return t, ok
+}
+
+// date resolves val, the data of item, to a Date if item is a Julian Date.
+// It returns false if the Julian Date does not exist. The zero Date is
+// returned for other items.
+func (b *BCBP) date(item item, val string) (Date, bool) {
+	switch item.id {
+	case dateOfFlight:
+		// item.validate() ensures val is a number, no need to check error
+		d, _ := strconv.Atoi(val)
+		ref := b.opts.referenceTime
+		t, ok := julianDate(ref, ref.Year(), 1, d)
+		return DateOf(t), ok
+	case dateOfIssueOfBoardingPass:
+		// The date of issue may be left blank.
+		if val == "" {
+			return 0, true
+		}
+
+		// item.validate() ensures val is a number, no need to check error
+		y, _ := strconv.Atoi(val[:1])
+		d, _ := strconv.Atoi(val[1:])
+		t, ok := julianDate(b.opts.referenceTime, y, 10, d)
+		return DateOf(t), ok
+	}
+	return 0, true
+}
+
+// abs returns the absolute value of d.
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,214 @@
+package bcbp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJulianDate(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  time.Time
+		year int
+		step int
+		day  int
+		want time.Time
+	}{
+		{
+			name: "same year",
+			ref:  time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC),
+			year: 2021,
+			step: 1,
+			day:  326,
+			want: time.Date(2021, time.November, 22, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "previous year",
+			ref:  time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC),
+			year: 2022,
+			step: 1,
+			day:  364,
+			want: time.Date(2021, time.December, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "next year",
+			ref:  time.Date(2021, time.December, 30, 0, 0, 0, 0, time.UTC),
+			year: 2021,
+			step: 1,
+			day:  2,
+			want: time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap day",
+			ref:  time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+			year: 2021,
+			step: 1,
+			day:  366,
+			want: time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "year digit in same decade",
+			ref:  time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC),
+			year: 1,
+			step: 10,
+			day:  325,
+			want: time.Date(2021, time.November, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "year digit in previous decade",
+			ref:  time.Date(2030, time.January, 2, 0, 0, 0, 0, time.UTC),
+			year: 9,
+			step: 10,
+			day:  364,
+			want: time.Date(2029, time.December, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "year digit in next decade",
+			ref:  time.Date(2029, time.December, 30, 0, 0, 0, 0, time.UTC),
+			year: 0,
+			step: 10,
+			day:  2,
+			want: time.Date(2030, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day 366 without leap years",
+			ref:  time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC),
+			year: 2026,
+			step: 1,
+			day:  366,
+		},
+		{
+			name: "day 366 without leap years ending in year",
+			ref:  time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC),
+			year: 3,
+			step: 10,
+			day:  366,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := julianDate(tt.ref, tt.year, tt.step, tt.day)
+			if ok != !tt.want.IsZero() || !got.Equal(tt.want) {
+				t.Errorf("julianDate() = %v, %t, want %v", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_Time(t *testing.T) {
+	got, err := Date(20211122).Time()
+	if err != nil {
+		t.Fatalf("Time() returned unexpected error: %+v", err)
+	}
+	want := time.Date(2021, time.November, 22, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Time() = %v, want %v", got, want)
+	}
+
+	for _, d := range []Date{0, 20211322, 20210229} {
+		if _, err := d.Time(); err == nil {
+			t.Errorf("Date(%d).Time() = nil: expected error", uint64(d))
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Date
+		wantErr bool
+	}{
+		{s: "2021-11-22", want: 20211122},
+		{s: "", want: 0},
+		{s: "22/11/2021", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseDate(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDate() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDate() = %d, want %d", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.s {
+				t.Errorf("String() = %q, want %q", got.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	type dates struct {
+		DateOfFlight Date `json:"date_of_flight"`
+		DateOfIssue  Date `json:"date_of_issue,omitempty"`
+	}
+
+	in := dates{DateOfFlight: 20211122}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned unexpected error: %+v", err)
+	}
+	if want := `{"date_of_flight":"2021-11-22"}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var out dates
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal() returned unexpected error: %+v", err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", out, in)
+	}
+}
+
+func TestDate_JulianDate(t *testing.T) {
+	tests := []struct {
+		date      Date
+		yearDigit int
+		day       int
+	}{
+		{date: 20210101, yearDigit: 1, day: 1},
+		{date: 20211122, yearDigit: 1, day: 326},
+		{date: 20201231, yearDigit: 0, day: 366},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date.String(), func(t *testing.T) {
+			yearDigit, day, err := tt.date.JulianDate()
+			if err != nil {
+				t.Fatalf("JulianDate() returned unexpected error: %+v", err)
+			}
+			if yearDigit != tt.yearDigit || day != tt.day {
+				t.Errorf("JulianDate() = %d, %d, want %d, %d", yearDigit, day, tt.yearDigit, tt.day)
+			}
+		})
+	}
+}
+
+func TestDate_Decoded(t *testing.T) {
+	const s = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 111>50B1WW1325B   00"
+	b, err := FromStrWithOptions(s, WithReferenceTime(referenceTime))
+	if err != nil {
+		t.Fatalf("FromStrWithOptions() returned unexpected error: %+v", err)
+	}
+
+	// Decoded dates are equal to the same day created in any other way.
+	if got, want := b.Legs[0].DateOfFlight, DateOf(time.Date(2021, time.November, 22, 0, 0, 0, 0, time.UTC)); got != want {
+		t.Errorf("DateOfFlight = %v, want %v", got, want)
+	}
+	if got, want := b.DateOfIssueOfBoardingPass, Date(20211121); got != want {
+		t.Errorf("DateOfIssueOfBoardingPass = %v, want %v", got, want)
+	}
+
+	// JulianDate returns the Julian Dates that were decoded.
+	if _, day, err := b.Legs[0].DateOfFlight.JulianDate(); err != nil || day != 326 {
+		t.Errorf("DateOfFlight.JulianDate() = %d, %v, want 326, nil", day, err)
+	}
+	if yearDigit, day, err := b.DateOfIssueOfBoardingPass.JulianDate(); err != nil || yearDigit != 1 || day != 325 {
+		t.Errorf("DateOfIssueOfBoardingPass.JulianDate() = %d, %d, %v, want 1, 325, nil", yearDigit, day, err)
+	}
+}
@@ -0,0 +1,244 @@
+package bcbp
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// Result is the result of decoding a Bar Coded Boarding Pass read by a
+// Decoder.
+type Result struct {
+	// BCBP is the decoded Bar Coded Boarding Pass. It may be partially
+	// decoded if Err is not nil.
+	BCBP BCBP
+
+	// Line is the number of the record, starting at 1, that holds the Bar
+	// Coded Boarding Pass. It is the line number when records are delimited
+	// by newlines.
+	Line int
+
+	// Err is the error returned by FromStrWithOptions.
+	Err error
+}
+
+// DecoderOption configures a Decoder.
+type DecoderOption func(*Decoder)
+
+// WithWorkers sets the number of Bar Coded Boarding Passes decoded
+// concurrently. It defaults to runtime.GOMAXPROCS(0).
+func WithWorkers(n int) DecoderOption {
+	return func(d *Decoder) {
+		if n > 0 {
+			d.workers = n
+		}
+	}
+}
+
+// WithSplit sets the function used to split the input into records. It
+// defaults to bufio.ScanLines.
+func WithSplit(split bufio.SplitFunc) DecoderOption {
+	return func(d *Decoder) {
+		d.split = split
+	}
+}
+
+// WithDecodeOptions sets the options used to decode each Bar Coded Boarding
+// Pass.
+func WithDecodeOptions(opts ...Option) DecoderOption {
+	return func(d *Decoder) {
+		d.opts = opts
+	}
+}
+
+// Decoder reads Bar Coded Boarding Passes from an io.Reader and decodes them
+// concurrently. Results are delivered in the order the Bar Coded Boarding
+// Passes are read. Empty records are skipped.
+//
+// Results are read either using Next and Result, similar to bufio.Scanner,
+// or from the channel returned by Results. Only one of them may be used.
+//
+//   dec := bcbp.NewDecoder(r, bcbp.WithWorkers(8))
+//   defer dec.Close()
+//   for dec.Next(ctx) {
+//       res := dec.Result()
+//   }
+//   if err := dec.Err(); err != nil {
+//       ...
+//   }
+type Decoder struct {
+	r       io.Reader
+	workers int
+	split   bufio.SplitFunc
+	opts    []Option
+
+	once    sync.Once
+	cancel  context.CancelFunc
+	results chan Result
+	cur     Result
+	err     error
+}
+
+// NewDecoder returns a Decoder that reads from r.
+func NewDecoder(r io.Reader, opts ...DecoderOption) *Decoder {
+	d := &Decoder{
+		r:       r,
+		workers: runtime.GOMAXPROCS(0),
+		split:   bufio.ScanLines,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// job is a Bar Coded Boarding Pass to be decoded by a worker. The result is
+// sent to out.
+type job struct {
+	line int
+	s    string
+	out  chan Result
+}
+
+// start starts reading and decoding r the first time it is called. The
+// pipeline stops when ctx is cancelled or Close is called.
+func (d *Decoder) start(ctx context.Context) {
+	d.once.Do(func() {
+		ctx, d.cancel = context.WithCancel(ctx)
+		d.results = make(chan Result)
+
+		// pending holds the output of every job in the order they were
+		// read. Its capacity bounds the number of jobs in flight.
+		jobs := make(chan job)
+		pending := make(chan chan Result, 2*d.workers)
+		readErr := make(chan error, 1)
+
+		go d.read(ctx, jobs, pending, readErr)
+		for i := 0; i < d.workers; i++ {
+			go d.work(jobs)
+		}
+		go d.collect(ctx, pending, readErr)
+	})
+}
+
+// read reads records from d.r and sends them to jobs and their output to
+// pending. Any error reading d.r is sent to errc.
+func (d *Decoder) read(ctx context.Context, jobs chan<- job, pending chan<- chan Result, errc chan<- error) {
+	defer close(pending)
+	defer close(jobs)
+
+	sc := bufio.NewScanner(d.r)
+	sc.Split(d.split)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+
+		j := job{line: line, s: sc.Text(), out: make(chan Result, 1)}
+		select {
+		case pending <- j.out:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case jobs <- j:
+		case <-ctx.Done():
+			return
+		}
+	}
+	errc <- sc.Err()
+}
+
+// work decodes every job until jobs is closed.
+func (d *Decoder) work(jobs <-chan job) {
+	for j := range jobs {
+		b, err := FromStrWithOptions(j.s, d.opts...)
+		j.out <- Result{BCBP: b, Line: j.line, Err: err}
+	}
+}
+
+// collect sends the output of every job in pending to d.results in order.
+func (d *Decoder) collect(ctx context.Context, pending <-chan chan Result, errc <-chan error) {
+	defer close(d.results)
+
+	for out := range pending {
+		var res Result
+		select {
+		case res = <-out:
+		case <-ctx.Done():
+			d.err = ctx.Err()
+			return
+		}
+
+		select {
+		case d.results <- res:
+		case <-ctx.Done():
+			d.err = ctx.Err()
+			return
+		}
+	}
+
+	// pending is also closed when ctx is cancelled, in which case there is
+	// no read error.
+	select {
+	case d.err = <-errc:
+	default:
+		d.err = ctx.Err()
+	}
+}
+
+// Results starts decoding and returns the channel results are delivered on.
+// The channel is closed once every Bar Coded Boarding Pass has been decoded,
+// reading fails, or ctx is cancelled. Err reports why.
+func (d *Decoder) Results(ctx context.Context) <-chan Result {
+	d.start(ctx)
+	return d.results
+}
+
+// Next advances to the next result, which is then available through Result.
+// Decoding starts on the first call. false is returned once every Bar Coded
+// Boarding Pass has been decoded, reading fails, or ctx is cancelled. Err
+// reports why.
+func (d *Decoder) Next(ctx context.Context) bool {
+	d.start(ctx)
+	select {
+	case res, ok := <-d.results:
+		d.cur = res
+		return ok
+	case <-ctx.Done():
+		d.Close()
+		return false
+	}
+}
+
+// Result returns the most recent result read by Next.
+func (d *Decoder) Result() Result {
+	return d.cur
+}
+
+// Err returns the error that stopped decoding, either an error reading the
+// input or the error of the context. It is nil if every Bar Coded Boarding
+// Pass was read. Err must only be called once Next returns false or the
+// channel returned by Results is closed.
+func (d *Decoder) Err() error {
+	if d.results != nil {
+		// Wait for the pipeline to stop.
+		for range d.results {
+		}
+	}
+	return d.err
+}
+
+// Close stops decoding. It must be called if results are not read until the
+// end, unless the context has been cancelled.
+func (d *Decoder) Close() {
+	// Decoding never starts if Close is called first.
+	d.once.Do(func() {
+		d.results = make(chan Result)
+		close(d.results)
+		d.cancel = func() {}
+	})
+	d.cancel()
+}
@@ -0,0 +1,174 @@
+package bcbp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+const decoderMandatory = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100"
+
+// decoderInput returns n Bar Coded Boarding Passes separated by sep. Every
+// third pass is invalid and every fifth record is empty. The flight number of
+// each valid pass is its record number.
+func decoderInput(n int, sep string) string {
+	var sb strings.Builder
+	for line := 1; line <= n; line++ {
+		switch {
+		case line%5 == 0:
+		case line%3 == 0:
+			sb.WriteString("X" + decoderMandatory[1:])
+		default:
+			sb.WriteString(strings.Replace(decoderMandatory, "0834", fmt.Sprintf("%04d", line), 1))
+		}
+		sb.WriteString(sep)
+	}
+	return sb.String()
+}
+
+// checkResults checks that results are in order and decoded as expected by
+// decoderInput.
+func checkResults(t *testing.T, results []Result, n int) {
+	t.Helper()
+
+	want := n - n/5
+	if len(results) != want {
+		t.Fatalf("got %d results, want %d", len(results), want)
+	}
+
+	prev := 0
+	for _, res := range results {
+		if res.Line <= prev || res.Line%5 == 0 {
+			t.Fatalf("unexpected line %d after line %d", res.Line, prev)
+		}
+		prev = res.Line
+
+		if res.Line%3 == 0 {
+			if res.Err == nil {
+				t.Errorf("line %d: expected error", res.Line)
+			}
+			continue
+		}
+		if res.Err != nil {
+			t.Errorf("line %d: unexpected error: %+v", res.Line, res.Err)
+			continue
+		}
+		if got := res.BCBP.Legs[0].FlightNumber.Number(); got != res.Line {
+			t.Errorf("line %d: FlightNumber = %d", res.Line, got)
+		}
+	}
+}
+
+func TestDecoder_Next(t *testing.T) {
+	const n = 1000
+	dec := NewDecoder(strings.NewReader(decoderInput(n, "\r\n")), WithWorkers(8))
+	defer dec.Close()
+
+	var results []Result
+	for dec.Next(context.Background()) {
+		results = append(results, dec.Result())
+	}
+	if err := dec.Err(); err != nil {
+		t.Fatalf("Err() returned unexpected error: %+v", err)
+	}
+	checkResults(t, results, n)
+}
+
+func TestDecoder_Results(t *testing.T) {
+	const n = 500
+
+	// Records are delimited by NUL characters.
+	split := func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, 0); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+	dec := NewDecoder(strings.NewReader(decoderInput(n, "\x00")), WithWorkers(3), WithSplit(split))
+
+	var results []Result
+	for res := range dec.Results(context.Background()) {
+		results = append(results, res)
+	}
+	if err := dec.Err(); err != nil {
+		t.Fatalf("Err() returned unexpected error: %+v", err)
+	}
+	checkResults(t, results, n)
+}
+
+func TestDecoder_DecodeOptions(t *testing.T) {
+	dec := NewDecoder(strings.NewReader("X"+decoderMandatory[1:]+"\n"), WithDecodeOptions(WithLenientDecoding()))
+	if !dec.Next(context.Background()) {
+		t.Fatalf("Next() = false, want true: %v", dec.Err())
+	}
+
+	var errs DecodeErrors
+	if err := dec.Result().Err; !errors.As(err, &errs) {
+		t.Errorf("Result().Err = %v, want DecodeErrors", err)
+	}
+	if dec.Next(context.Background()) {
+		t.Error("Next() = true, want false")
+	}
+}
+
+func TestDecoder_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dec := NewDecoder(strings.NewReader(decoderInput(1000, "\n")), WithWorkers(2))
+	if !dec.Next(ctx) {
+		t.Fatalf("Next() = false, want true: %v", dec.Err())
+	}
+
+	cancel()
+	for dec.Next(ctx) {
+	}
+	if err := dec.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDecoder_Close(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(decoderInput(10, "\n")))
+	dec.Close()
+	if dec.Next(context.Background()) {
+		t.Error("Next() = true after Close, want false")
+	}
+}
+
+// errReader returns err once every byte of r has been read.
+type errReader struct {
+	r   io.Reader
+	err error
+}
+
+func (e errReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	if err == io.EOF {
+		return n, e.err
+	}
+	return n, err
+}
+
+func TestDecoder_ReadError(t *testing.T) {
+	readErr := errors.New("read failed")
+	dec := NewDecoder(errReader{r: strings.NewReader(decoderMandatory + "\n"), err: readErr})
+
+	var results []Result
+	for dec.Next(context.Background()) {
+		results = append(results, dec.Result())
+	}
+	if len(results) != 1 {
+		t.Errorf("got %d results, want 1", len(results))
+	}
+	if err := dec.Err(); !errors.Is(err, readErr) {
+		t.Errorf("Err() = %v, want %v", err, readErr)
+	}
+}
@@ -1,3 +1,4 @@
 // Package bcbp decodes data based on the IATA 792 Bar Coded Boarding Pass
-// version 5 specification into a structured format.
+// version 5 specification into a structured format, and encodes the
+// structured format back into Bar Coded Boarding Pass data.
 package bcbp
@@ -0,0 +1,293 @@
+package bcbp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Encode encodes b into an IATA 792 Bar Coded Boarding Pass. It is the
+// inverse of FromStr.
+//
+// If b was decoded and its fields were not modified since, the data it was
+// decoded from is returned as is. This preserves the trailing whitespaces of
+// conditional items and the lengths of sub-sections that the encoded data
+// chose, which a signature may cover.
+//
+// Otherwise, every fixed length item is padded with trailing whitespaces and the length
+// of each sub-section is computed from the data it holds. Conditional items
+// that are empty and that are not followed by a non-empty item are omitted.
+// The security section is only encoded if either TypeOfSecurityData or
+// SecurityData is set.
+func (b *BCBP) Encode() (string, error) {
+	if data, _, ok := b.decoded(); ok {
+		return data, nil
+	}
+	return b.encode()
+}
+
+// encode encodes the fields of b regardless of the data it was decoded from.
+func (b *BCBP) encode() (string, error) {
+	s, err := b.encodeData()
+	if err != nil {
+		return "", err
+	}
+
+	if b.TypeOfSecurityData == "" && b.SecurityData == "" {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(s)
+
+	if err := b.writeSecurity(&sb); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// encodeData encodes the mandatory and conditional items of b. That is,
+// everything that precedes the security section.
+func (b *BCBP) encodeData() (string, error) {
+	if b.NumberOfLegsEncoded < 1 || b.NumberOfLegsEncoded > uint(len(b.Legs)) {
+		return "", InvalidFieldValue(
+			spec[numberOfLegsEncoded],
+			strconv.FormatUint(uint64(b.NumberOfLegsEncoded), 10))
+	}
+
+	// Single-leg boarding passes can only encode one leg.
+	if b.FormatCode == FormatCodeSingle && b.NumberOfLegsEncoded != 1 {
+		return "", InvalidFieldValue(
+			singleLegNumberOfLegsEncoded,
+			strconv.FormatUint(uint64(b.NumberOfLegsEncoded), 10))
+	}
+
+	var sb strings.Builder
+	for leg := 0; leg < int(b.NumberOfLegsEncoded); leg++ {
+		for _, item := range spec[:fieldSizeOfVariableSizeField+1] {
+			if item.unique() && leg > 0 {
+				continue
+			}
+
+			if err := b.writeItem(&sb, item, leg); err != nil {
+				return "", err
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// writeSecurity encodes the security items of b and writes them to sb.
+func (b *BCBP) writeSecurity(sb *strings.Builder) error {
+	// Security items start after fieldSizeOfVariableSizeField in spec.
+	for _, item := range spec[fieldSizeOfVariableSizeField+1:] {
+		if err := b.writeItem(sb, item, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeItem encodes item and writes it to sb. The item is required to be
+// valid regardless of whether it holds data.
+func (b *BCBP) writeItem(sb *strings.Builder, item item, leg int) error {
+	val, ok, err := b.encodeItem(item, leg)
+	if err != nil {
+		return err
+	}
+	if !ok && item.items == nil && !item.validate(val) {
+		return InvalidFieldValue(item, val)
+	}
+	sb.WriteString(val)
+	return nil
+}
+
+// encodeItem encodes the value of the field associated with item. Items that
+// define a sub-section are encoded as the hex length of the sub-section
+// followed by the encoded sub-section.
+//
+// ok reports whether the item holds any data. Items that do not hold data are
+// not validated since conditional items may be omitted; it is up to the
+// caller to validate them if they are not.
+func (b *BCBP) encodeItem(item item, leg int) (val string, ok bool, err error) {
+	if item.items == nil {
+		val, err = b.fieldByItem(item, leg)
+		if err != nil {
+			return "", false, err
+		}
+
+		switch item.id {
+		case beginningOfVersionNumber, beginningOfSecurityData:
+			// Markers do not hold any data on their own.
+			ok = false
+		default:
+			ok = val != ""
+		}
+
+		// forIndividualAirlineUse and securityData do not have a static
+		// length. Every other item is left justified with trailing
+		// whitespaces.
+		if item.length > 0 {
+			if len(val) > item.length {
+				return "", false, InvalidFieldValue(item, val)
+			}
+			val += whitespace(item.length - len(val))
+		}
+
+		if ok && !item.validate(val) {
+			return "", false, InvalidFieldValue(item, val)
+		}
+		return val, ok, nil
+	}
+
+	// Encode every sub-item of the sub-section and keep track of the last
+	// sub-item that holds data. Trailing sub-items without data are omitted.
+	var idx []int
+	var vals []string
+	last := -1
+	for i, subItem := range item.items {
+		if subItem.unique() && leg > 0 {
+			continue
+		}
+
+		subVal, subOK, err := b.encodeItem(subItem, leg)
+		if err != nil {
+			return "", false, err
+		}
+
+		// Items that are not part of the layout of VersionNumber cannot be
+		// encoded.
+		if !subItem.inLayout(layout(b.VersionNumber)) {
+			if subOK {
+				return "", false, UnsupportedItem(subItem, subVal, b.VersionNumber)
+			}
+			continue
+		}
+
+		idx = append(idx, i)
+		vals = append(vals, subVal)
+		if subOK {
+			last = len(vals) - 1
+		}
+	}
+
+	// Sub-items without data that are followed by a sub-item holding data
+	// cannot be omitted, therefore, they must be valid. Sub-sections are
+	// already validated by encodeItem.
+	var sb strings.Builder
+	for i := 0; i <= last; i++ {
+		subItem := item.items[idx[i]]
+		if subItem.items == nil && !subItem.validate(vals[i]) {
+			return "", false, InvalidFieldValue(subItem, vals[i])
+		}
+		sb.WriteString(vals[i])
+	}
+
+	size := fmt.Sprintf("%02X", sb.Len())
+	if !item.validate(size) {
+		return "", false, InvalidFieldValue(item, size)
+	}
+	return size + sb.String(), last >= 0, nil
+}
+
+// fieldByItem returns the value of the appropriate field based on the item
+// ID. It is the inverse of setFieldByItem.
+func (b *BCBP) fieldByItem(item item, leg int) (string, error) {
+	switch item.id {
+	case formatCode:
+		return b.FormatCode, nil
+	case numberOfLegsEncoded:
+		return strconv.FormatUint(uint64(b.NumberOfLegsEncoded), 10), nil
+	case passengerName:
+		return string(b.PassengerName), nil
+	case electronicTicketIndicator:
+		return string(b.ElectronicTicketIndicator), nil
+	case operatingCarrierPNRCode:
+		return b.Legs[leg].OperatingCarrierPNRCode, nil
+	case fromCityAirportCode:
+		return b.Legs[leg].FromCityAirportCode, nil
+	case toCityAirportCode:
+		return b.Legs[leg].ToCityAirportCode, nil
+	case operatingCarrierDesignator:
+		return b.Legs[leg].OperatingCarrierDesignator, nil
+	case flightNumber:
+		return string(b.Legs[leg].FlightNumber), nil
+	case dateOfFlight:
+		_, day, err := b.Legs[leg].DateOfFlight.JulianDate()
+		if err != nil {
+			return "", InvalidFieldValue(item, b.Legs[leg].DateOfFlight.String())
+		}
+		return fmt.Sprintf("%03d", day), nil
+	case compartmentCode:
+		return b.Legs[leg].CompartmentCode, nil
+	case seatNumber:
+		return string(b.Legs[leg].SeatNumber), nil
+	case checkinSequenceNumber:
+		return string(b.Legs[leg].CheckInSequenceNumber), nil
+	case passengerStatus:
+		return b.Legs[leg].PassengerStatus, nil
+	case beginningOfVersionNumber:
+		return ">", nil
+	case versionNumber:
+		if b.VersionNumber == 0 {
+			return "", nil
+		}
+		return strconv.FormatUint(uint64(b.VersionNumber), 10), nil
+	case passengerDescription:
+		return string(b.PassengerDescription), nil
+	case sourceOfCheckin:
+		return string(b.SourceOfCheckIn), nil
+	case sourceOfBoardingPassIssuance:
+		return string(b.SourceOfBoardingPassIssuance), nil
+	case dateOfIssueOfBoardingPass:
+		// The date of issue may be left blank.
+		if b.DateOfIssueOfBoardingPass.IsZero() {
+			return "", nil
+		}
+
+		year, day, err := b.DateOfIssueOfBoardingPass.JulianDate()
+		if err != nil {
+			return "", InvalidFieldValue(item, b.DateOfIssueOfBoardingPass.String())
+		}
+		return fmt.Sprintf("%d%03d", year, day), nil
+	case documentType:
+		return string(b.DocumentType), nil
+	case airlineDesignatorOfBoardingPassIssuer:
+		return b.AirlineDesignatorOfBoardingPassIssuer, nil
+	case baggageTagLicensePlateNumber:
+		return b.BaggageTagLicensePlateNumber, nil
+	case firstNonConsecutiveBaggageTagLicensePlateNumber:
+		return b.FirstNonConsecutiveBaggageTagLicensePlateNumber, nil
+	case secondNonConsecutiveBaggageTagLicensePlateNumber:
+		return b.SecondNonConsecutiveBaggageTagLicensePlateNumber, nil
+	case airlineNumericCode:
+		return b.Legs[leg].AirlineNumericCode, nil
+	case documentFormSerialNumber:
+		return b.Legs[leg].DocumentFormSerialNumber, nil
+	case selecteeIndicator:
+		return string(b.Legs[leg].SelecteeIndicator), nil
+	case internationalDocumentationVerification:
+		return string(b.Legs[leg].InternationalDocumentationVerification), nil
+	case marketingCarrierDesignator:
+		return b.Legs[leg].MarketingCarrierDesignator, nil
+	case frequentFlyerAirlineDesignator:
+		return b.Legs[leg].FrequentFlyerAirlineDesignator, nil
+	case frequentFlyerNumber:
+		return b.Legs[leg].FrequentFlyerNumber, nil
+	case idadIndicator:
+		return string(b.Legs[leg].IDADIndicator), nil
+	case freeBaggageAllowance:
+		return b.Legs[leg].FreeBaggageAllowance, nil
+	case fastTrack:
+		return string(b.Legs[leg].FastTrack), nil
+	case forIndividualAirlineUse:
+		return b.Legs[leg].ForIndividualAirlineUse, nil
+	case beginningOfSecurityData:
+		return "^", nil
+	case typeOfSecurityData:
+		return b.TypeOfSecurityData, nil
+	case securityData:
+		return b.SecurityData, nil
+	}
+	return "", MalformedSpec(b.data, b.pos, item)
+}
@@ -0,0 +1,150 @@
+package bcbp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBCBP_Encode(t *testing.T) {
+	match, err := filepath.Glob("testdata/*.input")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, in := range match {
+		t.Run(in, func(t *testing.T) {
+			data, err := os.ReadFile(in)
+			if err != nil {
+				t.Errorf("failed reading .input file: %v", err)
+				return
+			}
+
+			b, err := FromStr(string(data))
+			if err != nil {
+				t.Errorf("FromStr(%s) returned unexpected error: %+v", data, err)
+				return
+			}
+
+			got, err := b.Encode()
+			if err != nil {
+				t.Errorf("Encode() returned unexpected error: %+v", err)
+				return
+			}
+
+			if diff := cmp.Diff(string(data), got); diff != "" {
+				t.Errorf("output mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBCBP_Encode_Modified(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "blank conditional items",
+			in:   "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 111>50B1WW1325B   00",
+			want: "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J002A0025 10C>5081WW1325B",
+		},
+		{
+			name: "empty sub-sections",
+			in:   "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 106>50000",
+			want: "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J002A0025 102>5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromStr(tt.in)
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", tt.in, err)
+			}
+
+			// Modifying a field and restoring it leaves the fields of b
+			// unchanged, so Encode still returns the original data.
+			b.Legs[0].SeatNumber = "002A"
+			b.Legs[0].SeatNumber = "001A"
+			if got, err := b.Encode(); err != nil || got != tt.in {
+				t.Errorf("Encode() = %q, %v, want %q, nil", got, err, tt.in)
+			}
+
+			// Once modified, the fields of b are encoded from scratch.
+			b.Legs[0].SeatNumber = "002A"
+			if got, err := b.Encode(); err != nil || got != tt.want {
+				t.Errorf("Encode() = %q, %v, want %q, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBCBP_Encode_Errors(t *testing.T) {
+	const s = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100"
+	tests := []struct {
+		name   string
+		modify func(b *BCBP)
+	}{
+		{
+			name:   "number of legs encoded",
+			modify: func(b *BCBP) { b.NumberOfLegsEncoded = 5 },
+		},
+		{
+			name: "single format with multiple legs",
+			modify: func(b *BCBP) {
+				b.FormatCode = FormatCodeSingle
+				b.NumberOfLegsEncoded = 2
+				b.Legs[1] = b.Legs[0]
+			},
+		},
+		{
+			name:   "passenger name too long",
+			modify: func(b *BCBP) { b.PassengerName = "DESMARAIS/LUCXXXXXXXX" },
+		},
+		{
+			name:   "date of flight",
+			modify: func(b *BCBP) { b.Legs[0].DateOfFlight = 20211322 },
+		},
+		{
+			name:   "missing version number",
+			modify: func(b *BCBP) { b.PassengerDescription = "1" },
+		},
+		{
+			name: "empty item followed by item holding data",
+			modify: func(b *BCBP) {
+				b.VersionNumber = 5
+				b.AirlineDesignatorOfBoardingPassIssuer = "AC"
+			},
+		},
+		{
+			name: "item not supported by version",
+			modify: func(b *BCBP) {
+				b.VersionNumber = 4
+				b.Legs[0].AirlineNumericCode = "014"
+				b.Legs[0].FastTrack = FastTrackYes
+			},
+		},
+		{
+			name:   "security data too long",
+			modify: func(b *BCBP) { b.SecurityData = string(make([]byte, 256)) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromStr(s)
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", s, err)
+			}
+
+			tt.modify(&b)
+			if _, err := b.Encode(); err == nil {
+				t.Error("Encode() = nil: expected error")
+			}
+		})
+	}
+}
@@ -1,23 +1,50 @@
 package bcbp
 
 import (
+	"crypto"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 )
 
 // DecodeError implements error interface and represents an error decoding a
-// Bar Coded Boarding Pass.
+// Bar Coded Boarding Pass. Use errors.Is to check its Type:
+//   if errors.Is(err, bcbp.ErrInvalidDataFormat) {
+//       ...
+//   }
 type DecodeError struct {
 	Type         ErrorType
 	BoardingPass string
-	pos          int
-	Item         string
-	got          string
-	Detail       string
+
+	// Position is the position, starting at 1, of the data in BoardingPass
+	// that caused the error. It is past the end of BoardingPass if data is
+	// missing.
+	Position int
+
+	// Item is the description of the item being decoded, e.g.
+	// "Date of Flight (Julian Date)". It is empty if the error is not
+	// specific to an item.
+	Item string
+
+	// ItemID identifies the item being decoded by its name in snake case,
+	// e.g. date_of_flight. Items that hold data are identified by the JSON
+	// key of their field. It is empty if Item is empty.
+	ItemID string
+
+	// Value is the data that caused the error.
+	Value string
+
+	// Expected describes the data that was expected, e.g. the format of
+	// Item. It is empty if the error is not caused by the data itself.
+	Expected string
+
+	got    string
+	Detail string
 }
 
-// ErrorType represents the type of error that occurred.
+// ErrorType represents the type of error that occurred. It implements the
+// error interface so that it can be used as a target of errors.Is.
 type ErrorType string
 
 const (
@@ -36,7 +63,7 @@ const (
 	ErrNonASCII ErrorType = "ErrNonASCII"
 
 	// ErrUnsupportedBoardingPass is used when the Bar Coded Boarding Pass
-	// data is not an "M" type boarding pass.
+	// data is neither an "M" nor an "S" type boarding pass.
 	ErrUnsupportedBoardingPass ErrorType = "ErrUnsupportedBoardingPass"
 
 	// ErrUnexpectedEndOfInput is used when processing an item where the
@@ -55,9 +82,35 @@ const (
 	// data. This error is more of a warning that indicates that there
 	// is extra data in the Bar Coded Boarding Pass.
 	ErrUnknownData ErrorType = "ErrUnknownData"
+
+	// ErrUnknownCode is used when decoding using WithReferenceData and an
+	// airport or airline code is unknown by the reference data.
+	ErrUnknownCode ErrorType = "ErrUnknownCode"
+
+	// ErrMissingSecurityData is used when verifying a Bar Coded Boarding Pass
+	// that does not have a security section.
+	ErrMissingSecurityData ErrorType = "ErrMissingSecurityData"
+
+	// ErrUnknownKey is used when verifying a Bar Coded Boarding Pass and
+	// there is no public key for the issuer and type of security data.
+	ErrUnknownKey ErrorType = "ErrUnknownKey"
+
+	// ErrMalformedSignature is used when verifying a Bar Coded Boarding Pass
+	// and the security data cannot be decoded into a signature.
+	ErrMalformedSignature ErrorType = "ErrMalformedSignature"
+
+	// ErrInvalidSignature is used when verifying a Bar Coded Boarding Pass
+	// and the signature does not match the boarding pass data.
+	ErrInvalidSignature ErrorType = "ErrInvalidSignature"
+
+	// ErrUnsupportedSigner is used when signing a Bar Coded Boarding Pass
+	// with a signer that is not supported or whose signatures may not fit in
+	// the security data.
+	ErrUnsupportedSigner ErrorType = "ErrUnsupportedSigner"
 )
 
 // String converts ErrorType into a human readable prettyPrint.
+// Unrecognized ErrorTypes are printed as ErrorType("value").
 func (et ErrorType) String() string {
 	switch et {
 	case ErrInvalidDataFormat:
@@ -74,13 +127,153 @@ func (et ErrorType) String() string {
 		return "Malformed spec"
 	case ErrUnknownData:
 		return "Unknown data"
+	case ErrUnknownCode:
+		return "Unknown code"
+	case ErrMissingSecurityData:
+		return "Missing security data"
+	case ErrUnknownKey:
+		return "Unknown key"
+	case ErrMalformedSignature:
+		return "Malformed signature"
+	case ErrInvalidSignature:
+		return "Invalid signature"
+	case ErrUnsupportedSigner:
+		return "Unsupported signer"
 	default:
-		panic(fmt.Sprintf("unrecognized decode error type: %q", string(et)))
+		return fmt.Sprintf("ErrorType(%q)", string(et))
 	}
 }
 
+var _ error = ErrorType("")
+
+// Error returns the human readable description of et.
+func (et ErrorType) Error() string {
+	return et.String()
+}
+
 var _ error = &DecodeError{}
 
+// Is reports whether target is the ErrorType of de.
+func (de *DecodeError) Is(target error) bool {
+	et, ok := target.(ErrorType)
+	return ok && et == de.Type
+}
+
+// DecodeErrors implements error interface and represents every error that
+// occurred decoding a Bar Coded Boarding Pass using WithLenientDecoding.
+type DecodeErrors []*DecodeError
+
+var _ error = DecodeErrors{}
+
+// Error returns the pretty printed error report of every error.
+func (des DecodeErrors) Error() string {
+	var sb strings.Builder
+	for i, de := range des {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(de.Error())
+	}
+	return sb.String()
+}
+
+// Is reports whether any of the errors is target. Unlike Unwrap, it is also
+// used by errors.Is before Go 1.20.
+func (des DecodeErrors) Is(target error) bool {
+	for _, de := range des {
+		if errors.Is(de, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error that matches target, and if so, sets target to
+// that error and returns true. Unlike Unwrap, it is also used by errors.As
+// before Go 1.20.
+func (des DecodeErrors) As(target interface{}) bool {
+	for _, de := range des {
+		if errors.As(de, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns every error so that they can be inspected using errors.Is
+// and errors.As.
+func (des DecodeErrors) Unwrap() []error {
+	errs := make([]error, len(des))
+	for i, de := range des {
+		errs[i] = de
+	}
+	return errs
+}
+
+// EncodeError implements error interface and represents an error encoding a
+// BCBP into a Bar Coded Boarding Pass.
+type EncodeError struct {
+	Item   string
+	value  string
+	Detail string
+}
+
+var _ error = &EncodeError{}
+
+// VerifyError implements error interface and represents an error verifying
+// the security data of a Bar Coded Boarding Pass.
+type VerifyError struct {
+	Type   ErrorType
+	Key    KeyID
+	Detail string
+}
+
+var _ error = &VerifyError{}
+
+// Is reports whether target is the ErrorType of ve.
+func (ve *VerifyError) Is(target error) bool {
+	et, ok := target.(ErrorType)
+	return ok && et == ve.Type
+}
+
+// Error returns the ErrorType, the key used to verify the Bar Coded Boarding
+// Pass, and the reason for the error.
+func (ve *VerifyError) Error() string {
+	return fmt.Sprintf(
+		"bcbp: %s: issuer %q, type of security data %q: %s",
+		ve.Type, ve.Key.Issuer, ve.Key.TypeOfSecurityData, ve.Detail)
+}
+
+// SignError implements error interface and represents an error signing a
+// Bar Coded Boarding Pass.
+type SignError struct {
+	Type   ErrorType
+	Key    KeyID
+	Detail string
+}
+
+var _ error = &SignError{}
+
+// Is reports whether target is the ErrorType of se.
+func (se *SignError) Is(target error) bool {
+	et, ok := target.(ErrorType)
+	return ok && et == se.Type
+}
+
+// Error returns the ErrorType, the type of security data being signed, and
+// the reason for the error.
+func (se *SignError) Error() string {
+	return fmt.Sprintf(
+		"bcbp: %s: type of security data %q: %s",
+		se.Type, se.Key.TypeOfSecurityData, se.Detail)
+}
+
+// Error returns the item that failed to be encoded, its value, and the reason
+// for the error.
+func (ee *EncodeError) Error() string {
+	return fmt.Sprintf("bcbp: cannot encode %q: got %q: %s", ee.Item, ee.value, ee.Detail)
+}
+
 var tmpl = `bcbp: %s:
   boarding pass data:
   | %q
@@ -96,7 +289,7 @@ var tmpl = `bcbp: %s:
 //   - actual value
 //   - detailed reason for error
 func (de *DecodeError) Error() string {
-	diff := fmt.Sprintf("%s^ got %s", whitespace(de.pos), de.got)
+	diff := fmt.Sprintf("%s^ got %s", whitespace(de.Position), de.got)
 	return fmt.Sprintf(tmpl, de.Type, de.BoardingPass, diff, de.Detail)
 }
 
@@ -117,8 +310,11 @@ func InvalidDataFormat(bp string, pos int, item item, value string) *DecodeError
 	return &DecodeError{
 		Type:         ErrInvalidDataFormat,
 		BoardingPass: bp,
-		pos:          pos,
+		Position:     pos,
 		Item:         item.description,
+		ItemID:       item.id.String(),
+		Value:        value,
+		Expected:     item.format,
 		got:          fmt.Sprintf("%q", value),
 		Detail:       fmt.Sprintf("data for %q must be %s", item.description, item.format),
 	}
@@ -131,7 +327,9 @@ func InsufficientData(bp string, length int) *DecodeError {
 	return &DecodeError{
 		Type:         ErrInsufficientData,
 		BoardingPass: bp,
-		pos:          length,
+		Position:     length,
+		Value:        bp,
+		Expected:     "at least 60 characters",
 		got:          fmt.Sprintf("%q character(s)", strconv.Itoa(length)),
 		Detail:       "boarding pass data must have at least 60 characters",
 	}
@@ -145,7 +343,9 @@ func NonASCII(bp string, pos int, val rune) *DecodeError {
 	return &DecodeError{
 		Type:         ErrNonASCII,
 		BoardingPass: bp,
-		pos:          pos,
+		Position:     pos,
+		Value:        string(val),
+		Expected:     "ASCII characters",
 		got:          fmt.Sprintf("%c", val),
 		Detail:       "boarding pass data must contain only ASCII characters",
 	}
@@ -153,16 +353,19 @@ func NonASCII(bp string, pos int, val rune) *DecodeError {
 
 // UnsupportedBoardingPass returns a *DecodeError indicating
 // "unsupported boarding pass". This is used to report that the boarding pass
-// is invalid because it is not an "M" type boarding pass.
+// is invalid because it is neither an "M" nor an "S" type boarding pass.
 //
-// "M" refers to multi-leg boarding pass.
+// "M" refers to multi-leg boarding pass. "S" refers to the legacy single-leg
+// boarding pass.
 func UnsupportedBoardingPass(bp string, value string) *DecodeError {
 	return &DecodeError{
 		Type:         ErrUnsupportedBoardingPass,
 		BoardingPass: bp,
-		pos:          1,
+		Position:     1,
+		Value:        value,
+		Expected:     `"M" or "S"`,
 		got:          fmt.Sprintf("%q", value),
-		Detail:       `boarding pass must be a "M" type`,
+		Detail:       `boarding pass must be a "M" or "S" type`,
 	}
 }
 
@@ -174,7 +377,11 @@ func UnexpectedEndOfInput(bp string, pos int, item item, value string, length in
 	return &DecodeError{
 		Type:         ErrUnexpectedEndOfInput,
 		BoardingPass: bp,
-		pos:          pos,
+		Position:     pos,
+		Item:         item.description,
+		ItemID:       item.id.String(),
+		Value:        value,
+		Expected:     fmt.Sprintf("at least %d character(s)", length),
 		got:          fmt.Sprintf("%q character(s)", strconv.Itoa(len(value))),
 		Detail: fmt.Sprintf(
 			"%q must have at least %d character(s)",
@@ -196,7 +403,9 @@ func MalformedSpec(bp string, pos int, item item) *DecodeError {
 	return &DecodeError{
 		Type:         ErrMalformedSpec,
 		BoardingPass: bp,
-		pos:          pos,
+		Position:     pos,
+		Item:         item.description,
+		ItemID:       item.id.String(),
 		got:          fmt.Sprintf("%q item defines sub-section", item.description),
 		Detail: fmt.Sprintf(
 			"only following items can define sub-sections:"+
@@ -217,8 +426,127 @@ func UnknownData(bp string, pos int, value string) *DecodeError {
 	return &DecodeError{
 		Type:         ErrUnknownData,
 		BoardingPass: bp,
-		pos:          pos,
+		Position:     pos,
+		Value:        value,
 		got:          fmt.Sprintf("%q character(s)", strconv.Itoa(len(value))),
 		Detail:       fmt.Sprintf("boarding pass successfully decoded but %q is unknown and has not been processed", value),
 	}
 }
+
+// UnknownCode returns a *DecodeError indicating "unknown code". This is used
+// to report that the airport or airline code of item is not known by the
+// reference data used to decode the Bar Coded Boarding Pass.
+func UnknownCode(bp string, pos int, item item, value string) *DecodeError {
+	return &DecodeError{
+		Type:         ErrUnknownCode,
+		BoardingPass: bp,
+		Position:     pos,
+		Item:         item.description,
+		ItemID:       item.id.String(),
+		Value:        value,
+		Expected:     "a code known by the reference data",
+		got:          fmt.Sprintf("%q", value),
+		Detail:       fmt.Sprintf("%q must be known by the reference data", item.description),
+	}
+}
+
+// InvalidFieldValue returns a *EncodeError indicating that the value of the
+// field associated with item does not match the data format as specified by
+// the IATA 792 resolution and therefore cannot be encoded.
+func InvalidFieldValue(item item, value string) *EncodeError {
+	return &EncodeError{
+		Item:   item.description,
+		value:  value,
+		Detail: fmt.Sprintf("data for %q must be %s", item.description, item.format),
+	}
+}
+
+// MissingSecurityData returns a *VerifyError indicating "missing security
+// data". This is used to report that a Bar Coded Boarding Pass cannot be
+// verified because it does not have a security section.
+func MissingSecurityData(key KeyID) *VerifyError {
+	return &VerifyError{
+		Type:   ErrMissingSecurityData,
+		Key:    key,
+		Detail: "boarding pass must have security data to be verified",
+	}
+}
+
+// UnknownKey returns a *VerifyError indicating "unknown key". This is used to
+// report that there is no public key for the issuer and type of security data
+// of a Bar Coded Boarding Pass.
+func UnknownKey(key KeyID) *VerifyError {
+	return &VerifyError{
+		Type:   ErrUnknownKey,
+		Key:    key,
+		Detail: "no public key found for issuer and type of security data",
+	}
+}
+
+// UnsupportedKey returns a *VerifyError indicating "unknown key". This is used
+// to report that the public key for the issuer and type of security data of a
+// Bar Coded Boarding Pass is not of a supported type.
+func UnsupportedKey(key KeyID, pub crypto.PublicKey) *VerifyError {
+	return &VerifyError{
+		Type:   ErrUnknownKey,
+		Key:    key,
+		Detail: fmt.Sprintf("public key of type %T is not supported", pub),
+	}
+}
+
+// MalformedSignature returns a *VerifyError indicating "malformed signature".
+// This is used to report that the security data of a Bar Coded Boarding Pass
+// cannot be decoded into a signature.
+func MalformedSignature(key KeyID, detail string) *VerifyError {
+	return &VerifyError{
+		Type:   ErrMalformedSignature,
+		Key:    key,
+		Detail: detail,
+	}
+}
+
+// InvalidSignature returns a *VerifyError indicating "invalid signature".
+// This is used to report that the signature in the security data of a Bar
+// Coded Boarding Pass does not match the boarding pass data.
+func InvalidSignature(key KeyID) *VerifyError {
+	return &VerifyError{
+		Type:   ErrInvalidSignature,
+		Key:    key,
+		Detail: "signature does not match boarding pass data",
+	}
+}
+
+// UnsupportedSigner returns a *SignError indicating "unsupported signer".
+// This is used to report that a Bar Coded Boarding Pass cannot be signed
+// because the public key of the signer is not of a supported type.
+func UnsupportedSigner(key KeyID, pub crypto.PublicKey) *SignError {
+	return &SignError{
+		Type:   ErrUnsupportedSigner,
+		Key:    key,
+		Detail: fmt.Sprintf("signer with public key of type %T is not supported", pub),
+	}
+}
+
+// UnsupportedSignerKeySize returns a *SignError indicating "unsupported
+// signer". This is used to report that a Bar Coded Boarding Pass cannot be
+// signed because signatures of up to n bytes may not fit in the security
+// data.
+func UnsupportedSignerKeySize(key KeyID, pub crypto.PublicKey, n int) *SignError {
+	return &SignError{
+		Type: ErrUnsupportedSigner,
+		Key:  key,
+		Detail: fmt.Sprintf("signatures of public key of type %T may be %d bytes long, security data holds at most %d base64 characters",
+			pub, n, maxSecurityDataLength),
+	}
+}
+
+// UnsupportedItem returns a *EncodeError indicating that the field associated
+// with item holds data but the item is not part of the layout of the version
+// of the IATA 792 resolution used to encode the Bar Coded Boarding Pass.
+func UnsupportedItem(item item, value string, version uint) *EncodeError {
+	return &EncodeError{
+		Item:   item.description,
+		value:  value,
+		Detail: fmt.Sprintf("%q is not supported by version %d", item.description, version),
+	}
+}
@@ -1,15 +1,183 @@
 package bcbp
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
 
 func TestErrorType_String(t *testing.T) {
-	defer func() {
-		r := recover()
-		if r == nil {
-			t.Errorf("errorType.String() = nil: expected error")
-		}
-	}()
+	tests := []struct {
+		et   ErrorType
+		want string
+	}{
+		{et: ErrInvalidDataFormat, want: "Invalid data format"},
+		{et: ErrUnknownCode, want: "Unknown code"},
+		{et: ErrInvalidSignature, want: "Invalid signature"},
+		{et: ErrUnsupportedSigner, want: "Unsupported signer"},
+		{et: ErrorType("test"), want: `ErrorType("test")`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.et), func(t *testing.T) {
+			if got := tt.et.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := tt.et.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	const mandatory = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100"
+
+	tests := []struct {
+		name string
+		in   string
+		want DecodeError
+	}{
+		{
+			name: "invalid data format",
+			in:   "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 367J001A0025 100",
+			want: DecodeError{
+				Type:     ErrInvalidDataFormat,
+				Position: 45,
+				Item:     "Date of Flight (Julian Date)",
+				ItemID:   "date_of_flight",
+				Value:    "367",
+				Expected: "3 digits with leading zeroes between 001 and 365 (366 for leap years)",
+			},
+		},
+		{
+			name: "insufficient data",
+			in:   mandatory[:59],
+			want: DecodeError{
+				Type:     ErrInsufficientData,
+				Position: 59,
+				Value:    mandatory[:59],
+				Expected: "at least 60 characters",
+			},
+		},
+		{
+			name: "non ASCII",
+			in:   "M1DÉSMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100",
+			want: DecodeError{
+				Type:     ErrNonASCII,
+				Position: 4,
+				Value:    "É",
+				Expected: "ASCII characters",
+			},
+		},
+		{
+			name: "unsupported boarding pass",
+			in:   "X" + mandatory[1:],
+			want: DecodeError{
+				Type:     ErrUnsupportedBoardingPass,
+				Position: 1,
+				Value:    "X",
+				Expected: `"M" or "S"`,
+			},
+		},
+		{
+			name: "unexpected end of input",
+			in:   "M2" + mandatory[2:],
+			want: DecodeError{
+				Type:     ErrUnexpectedEndOfInput,
+				Position: 61,
+				Item:     "Operating Carrier PNR Code",
+				ItemID:   "operating_carrier_pnr_code",
+				Expected: "at least 7 character(s)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromStr(tt.in)
+
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Fatalf("FromStr() = %v, want *DecodeError", err)
+			}
+			if !errors.Is(err, tt.want.Type) {
+				t.Errorf("errors.Is(%v) = false, want true", tt.want.Type)
+			}
+			if errors.Is(err, ErrUnknownData) {
+				t.Errorf("errors.Is(%v) = true, want false", ErrUnknownData)
+			}
+
+			tt.want.BoardingPass = tt.in
+			opts := cmpopts.IgnoreFields(DecodeError{}, "Detail", "got")
+			if diff := cmp.Diff(&tt.want, de, opts); diff != "" {
+				t.Errorf("FromStr() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeErrors_Is(t *testing.T) {
+	_, err := FromStrWithOptions("M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 1000", WithLenientDecoding())
 
-	et := ErrorType("test")
-	_ = et.String()
+	var errs DecodeErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("FromStrWithOptions() = %v, want DecodeErrors", err)
+	}
+	if !errs.Is(ErrInvalidDataFormat) {
+		t.Errorf("Is(%v) = false, want true", ErrInvalidDataFormat)
+	}
+	if errs.Is(ErrUnknownCode) {
+		t.Errorf("Is(%v) = true, want false", ErrUnknownCode)
+	}
+}
+
+func TestDecodeErrors_As(t *testing.T) {
+	_, err := FromStrWithOptions("M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326101AA0025 !00", WithLenientDecoding())
+
+	var errs DecodeErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("FromStrWithOptions() = %v, want DecodeErrors", err)
+	}
+
+	var de *DecodeError
+	if !errs.As(&de) {
+		t.Fatalf("As() = false, want true")
+	}
+	if de != errs[0] {
+		t.Errorf("As() set %v, want %v", de, errs[0])
+	}
+
+	var ve *VerifyError
+	if errs.As(&ve) {
+		t.Errorf("As(%T) = true, want false", ve)
+	}
+}
+
+func TestVerifyError_Is(t *testing.T) {
+	err := fmt.Errorf("verify: %w", InvalidSignature(KeyID{Issuer: "AC", TypeOfSecurityData: "1"}))
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("errors.Is(%v) = false, want true", ErrInvalidSignature)
+	}
+	if errors.Is(err, ErrUnknownKey) {
+		t.Errorf("errors.Is(%v) = true, want false", ErrUnknownKey)
+	}
+}
+
+func TestItemID_String(t *testing.T) {
+	seen := make(map[string]bool)
+	var walk func(items []item)
+	walk = func(items []item) {
+		for _, item := range items {
+			id := item.id.String()
+			if id == "" || seen[id] {
+				t.Errorf("%s: String() = %q, want a unique identifier", item.description, id)
+			}
+			seen[id] = true
+			walk(item.items)
+		}
+	}
+	walk(spec)
 }
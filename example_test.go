@@ -2,6 +2,7 @@ package bcbp_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/jandauz/boarding-pass"
 )
@@ -21,24 +22,41 @@ func ExampleFromStr() {
 	fmt.Println("ToCityAirportCode:", b.Legs[0].ToCityAirportCode)
 	fmt.Println("OperatingCarrierDesignator:", b.Legs[0].OperatingCarrierDesignator)
 	fmt.Println("FlightNumber:", b.Legs[0].FlightNumber)
-	fmt.Println("DateOfFlight:", b.Legs[0].DateOfFlight)
 	fmt.Println("CompartmentCode:", b.Legs[0].CompartmentCode)
 	fmt.Println("SeatNumber:", b.Legs[0].SeatNumber)
-	fmt.Println("CheckInSequenceNumber:", b.Legs[0].CheckInSequenceNumber)
+	fmt.Printf("CheckInSequenceNumber: %q\n", b.Legs[0].CheckInSequenceNumber)
 	fmt.Println("PassengerStatus:", b.Legs[0].PassengerStatus)
 	// Output:
 	// Format Code: M
 	// NumberOfLegsEncoded: 1
 	// PassengerName: DESMARAIS/LUC
-	// ElectronicTicketIndicator: E
+	// ElectronicTicketIndicator: Electronic ticket
 	// OperatingCarrierPNRCode: ABC123
 	// FromCityAirportCode: YUL
 	// ToCityAirportCode: FRA
 	// OperatingCarrierDesignator: AC
 	// FlightNumber: 0834
-	// DateOfFlight: 2021-11-22
 	// CompartmentCode: J
 	// SeatNumber: 001A
-	// CheckInSequenceNumber: 0025
+	// CheckInSequenceNumber: "0025 "
 	// PassengerStatus: 1
 }
+
+func ExampleFromStrWithOptions() {
+	const s = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100"
+	ref := time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC)
+	b, err := bcbp.FromStrWithOptions(s, bcbp.WithReferenceTime(ref))
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("DateOfFlight:", b.Legs[0].DateOfFlight)
+
+	t, err := b.Legs[0].DateOfFlight.Time()
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("Weekday:", t.Weekday())
+	// Output:
+	// DateOfFlight: 2021-11-22
+	// Weekday: Monday
+}
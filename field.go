@@ -0,0 +1,51 @@
+package bcbp
+
+// Field describes an item decoded from a Bar Coded Boarding Pass and where
+// it is located in the Bar Coded Boarding Pass data.
+type Field struct {
+	// Description is the name of the item as described by the IATA 792
+	// specification, e.g. "Passenger Name".
+	Description string
+
+	// Leg is the index of the flight segment the item belongs to. Unique
+	// items, such as Passenger Name, always belong to the first leg.
+	Leg int
+
+	// Offset is the index of the first character of the item in the Bar
+	// Coded Boarding Pass data.
+	Offset int
+
+	// Length is the number of characters of the item. For items that define
+	// a sub-section, such as "Field Size of variable size field", it does
+	// not include the sub-section.
+	Length int
+
+	// Raw is the value of the item as it appears in the Bar Coded Boarding
+	// Pass data including any leading or trailing whitespaces.
+	Raw string
+}
+
+// Fields returns every item decoded from b in the order they appear in the
+// Bar Coded Boarding Pass data.
+//
+// If b was decoded using FromStr and its fields were not modified since, the
+// original data is used. Otherwise, b is encoded first so that the returned
+// items describe its current fields. Items that are invalid when using
+// WithLenientDecoding are not returned.
+func (b BCBP) Fields() ([]Field, error) {
+	data, _, ok := b.decoded()
+	opts := b.opts
+	if !ok {
+		var err error
+		data, err = b.encode()
+		if err != nil {
+			return nil, err
+		}
+		opts = newOptions()
+	}
+
+	opts.fields = true
+	d := BCBP{opts: opts}
+	err := d.decode(data)
+	return d.fields, err
+}
@@ -0,0 +1,137 @@
+package bcbp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBCBP_Fields(t *testing.T) {
+	match, err := filepath.Glob("testdata/*.input")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, in := range match {
+		t.Run(in, func(t *testing.T) {
+			data, err := os.ReadFile(in)
+			if err != nil {
+				t.Errorf("failed reading .input file: %v", err)
+				return
+			}
+
+			b, err := FromStr(string(data))
+			if err != nil {
+				t.Errorf("FromStr(%s) returned unexpected error: %+v", data, err)
+				return
+			}
+
+			fields, err := b.Fields()
+			if err != nil {
+				t.Errorf("Fields() returned unexpected error: %+v", err)
+				return
+			}
+
+			// Fields are contiguous and make up the entire data.
+			var sb strings.Builder
+			for _, f := range fields {
+				if f.Offset != sb.Len() {
+					t.Errorf("%q.Offset = %d, want %d", f.Description, f.Offset, sb.Len())
+				}
+				if f.Length != len(f.Raw) {
+					t.Errorf("%q.Length = %d, want %d", f.Description, f.Length, len(f.Raw))
+				}
+				sb.WriteString(f.Raw)
+			}
+			if diff := cmp.Diff(string(data), sb.String()); diff != "" {
+				t.Errorf("output mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBCBP_Fields_Encoded(t *testing.T) {
+	b := BCBP{
+		FormatCode:                "M",
+		NumberOfLegsEncoded:       1,
+		PassengerName:             "DESMARAIS/LUC",
+		ElectronicTicketIndicator: ElectronicTicketIndicatorElectronicTicket,
+	}
+	b.Legs[0] = Leg{
+		OperatingCarrierPNRCode:    "ABC123",
+		FromCityAirportCode:        "YUL",
+		ToCityAirportCode:          "FRA",
+		OperatingCarrierDesignator: "AC",
+		FlightNumber:               "0834",
+		DateOfFlight:               20211122,
+		CompartmentCode:            "J",
+		SeatNumber:                 "001A",
+		CheckInSequenceNumber:      "0025",
+		PassengerStatus:            "1",
+	}
+
+	fields, err := b.Fields()
+	if err != nil {
+		t.Fatalf("Fields() returned unexpected error: %+v", err)
+	}
+
+	want := Field{
+		Description: "Passenger Name",
+		Offset:      2,
+		Length:      20,
+		Raw:         "DESMARAIS/LUC       ",
+	}
+	if diff := cmp.Diff(want, fields[2]); diff != "" {
+		t.Errorf("field mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBCBP_Fields_Modified(t *testing.T) {
+	const s = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100"
+	b, err := FromStr(s)
+	if err != nil {
+		t.Fatalf("FromStr(%s) returned unexpected error: %+v", s, err)
+	}
+	b.Legs[0].SeatNumber = "002A"
+
+	fields, err := b.Fields()
+	if err != nil {
+		t.Fatalf("Fields() returned unexpected error: %+v", err)
+	}
+
+	want := Field{
+		Description: "Seat Number",
+		Offset:      48,
+		Length:      4,
+		Raw:         "002A",
+	}
+	if diff := cmp.Diff(want, fields[11]); diff != "" {
+		t.Errorf("field mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBCBP_Fields_Lenient(t *testing.T) {
+	const s = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326101AA0025 100"
+	b, err := FromStrWithOptions(s, WithLenientDecoding())
+	if err == nil {
+		t.Fatalf("FromStr(%s) = nil: expected error", s)
+	}
+
+	// The invalid Compartment Code is not returned. Every other item is
+	// described as it appears in the original data.
+	fields, _ := b.Fields()
+	for _, f := range fields {
+		if f.Description == "Compartment Code" {
+			t.Errorf("Fields() returned invalid item %q", f.Raw)
+		}
+		if got := s[f.Offset : f.Offset+f.Length]; got != f.Raw {
+			t.Errorf("%q.Raw = %q, want %q", f.Description, f.Raw, got)
+		}
+	}
+	if len(fields) == 0 {
+		t.Error("Fields() returned no items")
+	}
+}
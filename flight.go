@@ -0,0 +1,114 @@
+package bcbp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlightNumber is the number of a flight. It is up to 4 digits with leading
+// zeroes followed by an optional alpha suffix, e.g. 0834 or 0834A.
+type FlightNumber string
+
+// maxFlightNumber is the highest number that can be encoded in a
+// FlightNumber.
+const maxFlightNumber = 9999
+
+// NewFlightNumber returns the FlightNumber of number and suffix with leading
+// zeroes, e.g. NewFlightNumber(834, 'A') returns 0834A. suffix is 0 if the
+// flight number does not have a suffix. An error is returned if number is
+// not between 0 and 9999 or suffix is not an alpha.
+func NewFlightNumber(number int, suffix byte) (FlightNumber, error) {
+	if number < 0 || number > maxFlightNumber {
+		return "", fmt.Errorf("bcbp: flight number %d must be between 0 and %d", number, maxFlightNumber)
+	}
+	if suffix == 0 {
+		return FlightNumber(fmt.Sprintf("%04d", number)), nil
+	}
+	if !isAlpha(suffix) {
+		return "", fmt.Errorf("bcbp: flight number suffix %q must be an alpha", suffix)
+	}
+	return FlightNumber(fmt.Sprintf("%04d%c", number, upper(suffix))), nil
+}
+
+// parse returns the number and the upper case suffix of f. ok is false if f
+// is not 1 to 4 digits followed by an optional alpha.
+func (f FlightNumber) parse() (number int, suffix byte, ok bool) {
+	s := strings.TrimSpace(string(f))
+	if s != "" && isAlpha(s[len(s)-1]) {
+		suffix = upper(s[len(s)-1])
+		s = s[:len(s)-1]
+	}
+	if len(s) < 1 || len(s) > 4 {
+		return 0, 0, false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, 0, false
+		}
+		number = number*10 + int(s[i]-'0')
+	}
+	return number, suffix, true
+}
+
+// Valid reports whether f is 1 to 4 digits followed by an optional alpha
+// suffix.
+func (f FlightNumber) Valid() bool {
+	_, _, ok := f.parse()
+	return ok
+}
+
+// Number returns the numeric part of f. 0 is returned if f is not Valid.
+func (f FlightNumber) Number() int {
+	number, _, _ := f.parse()
+	return number
+}
+
+// Suffix returns the upper case operational suffix of f. 0 is returned if f
+// does not have a suffix or is not Valid.
+func (f FlightNumber) Suffix() byte {
+	_, suffix, _ := f.parse()
+	return suffix
+}
+
+// Equal reports whether f and o have the same number and suffix, ignoring
+// leading zeroes and the case of the suffix. Invalid flight numbers are
+// never equal.
+func (f FlightNumber) Equal(o FlightNumber) bool {
+	n1, s1, ok1 := f.parse()
+	n2, s2, ok2 := o.parse()
+	return ok1 && ok2 && n1 == n2 && s1 == s2
+}
+
+// Canonical returns f prefixed by carrier without leading zeroes, e.g. AC834
+// or AC834A. f is returned as is, prefixed by carrier, if it is not Valid.
+func (f FlightNumber) Canonical(carrier string) string {
+	return f.format(carrier, "%s%d")
+}
+
+// Padded returns f prefixed by carrier with leading zeroes, e.g. AC0834 or
+// AC0834A. f is returned as is, prefixed by carrier, if it is not Valid.
+func (f FlightNumber) Padded(carrier string) string {
+	return f.format(carrier, "%s%04d")
+}
+
+// format returns f prefixed by carrier using layout to format the carrier and
+// the number of f. The suffix, if any, is appended.
+func (f FlightNumber) format(carrier, layout string) string {
+	carrier = strings.TrimSpace(carrier)
+	number, suffix, ok := f.parse()
+	if !ok {
+		return carrier + strings.TrimSpace(string(f))
+	}
+
+	s := fmt.Sprintf(layout, carrier, number)
+	if suffix != 0 {
+		s += string(suffix)
+	}
+	return s
+}
+
+// Flight returns FlightNumber prefixed by OperatingCarrierDesignator without
+// leading zeroes, e.g. AC834. See FlightNumber.Canonical.
+func (l Leg) Flight() string {
+	return l.FlightNumber.Canonical(l.OperatingCarrierDesignator)
+}
@@ -0,0 +1,101 @@
+package bcbp
+
+import "testing"
+
+func TestFlightNumber(t *testing.T) {
+	tests := []struct {
+		f         FlightNumber
+		valid     bool
+		number    int
+		suffix    byte
+		canonical string
+		padded    string
+	}{
+		{f: "0834", valid: true, number: 834, canonical: "AC834", padded: "AC0834"},
+		{f: "0834a", valid: true, number: 834, suffix: 'A', canonical: "AC834A", padded: "AC0834A"},
+		{f: "0834 ", valid: true, number: 834, canonical: "AC834", padded: "AC0834"},
+		{f: "12", valid: true, number: 12, canonical: "AC12", padded: "AC0012"},
+		{f: "0000", valid: true, canonical: "AC0", padded: "AC0000"},
+		{f: "A", canonical: "ACA", padded: "ACA"},
+		{f: "08X4", canonical: "AC08X4", padded: "AC08X4"},
+		{f: "12345", canonical: "AC12345", padded: "AC12345"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.f), func(t *testing.T) {
+			if got := tt.f.Valid(); got != tt.valid {
+				t.Errorf("Valid() = %t, want %t", got, tt.valid)
+			}
+			if got := tt.f.Number(); got != tt.number {
+				t.Errorf("Number() = %d, want %d", got, tt.number)
+			}
+			if got := tt.f.Suffix(); got != tt.suffix {
+				t.Errorf("Suffix() = %q, want %q", got, tt.suffix)
+			}
+			if got := tt.f.Canonical("AC "); got != tt.canonical {
+				t.Errorf("Canonical() = %q, want %q", got, tt.canonical)
+			}
+			if got := tt.f.Padded("AC "); got != tt.padded {
+				t.Errorf("Padded() = %q, want %q", got, tt.padded)
+			}
+		})
+	}
+}
+
+func TestNewFlightNumber(t *testing.T) {
+	tests := []struct {
+		number  int
+		suffix  byte
+		want    FlightNumber
+		wantErr bool
+	}{
+		{number: 834, want: "0834"},
+		{number: 834, suffix: 'a', want: "0834A"},
+		{number: 10000, wantErr: true},
+		{number: -1, wantErr: true},
+		{number: 834, suffix: '1', wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NewFlightNumber(tt.number, tt.suffix)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("NewFlightNumber(%d, %q) = %q: expected error", tt.number, tt.suffix, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewFlightNumber(%d, %q) returned unexpected error: %+v", tt.number, tt.suffix, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NewFlightNumber(%d, %q) = %q, want %q", tt.number, tt.suffix, got, tt.want)
+		}
+	}
+}
+
+func TestFlightNumber_Equal(t *testing.T) {
+	tests := []struct {
+		a, b FlightNumber
+		want bool
+	}{
+		{a: "0834", b: "834", want: true},
+		{a: "0834A", b: "834a", want: true},
+		{a: "0834", b: "0834A", want: false},
+		{a: "0834", b: "0835", want: false},
+		{a: "", b: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b); got != tt.want {
+			t.Errorf("%q.Equal(%q) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLeg_Flight(t *testing.T) {
+	l := Leg{OperatingCarrierDesignator: "AC", FlightNumber: "0834"}
+	if got, want := l.Flight(), "AC834"; got != want {
+		t.Errorf("Flight() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+//go:build go1.18
+// +build go1.18
+
+package bcbp
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// FuzzFromStr checks that FromStr never panics, that it only returns
+// *DecodeError errors, and that every Bar Coded Boarding Pass it decodes is
+// encoded back into the original data and, once its fields are re-encoded,
+// into an equivalent Bar Coded Boarding Pass.
+//
+// Crashers are committed as regression inputs under testdata/errors.
+func FuzzFromStr(f *testing.F) {
+	for _, in := range []string{"testdata/*.input", "testdata/errors/*.input", "testdata/lenient/*.input"} {
+		match, err := filepath.Glob(in)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, in := range match {
+			data, err := os.ReadFile(in)
+			if err != nil {
+				f.Fatalf("failed reading .input file: %v", err)
+			}
+			f.Add(string(data))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		b, err := FromStrWithOptions(s, WithReferenceTime(referenceTime))
+		if err != nil {
+			if _, ok := err.(*DecodeError); !ok {
+				t.Fatalf("FromStr() returned %T, want *DecodeError: %v", err, err)
+			}
+			checkLenient(t, s)
+			return
+		}
+
+		enc, err := b.Encode()
+		if err != nil {
+			t.Fatalf("Encode() returned unexpected error: %+v", err)
+		}
+		if enc != s {
+			t.Fatalf("Encode() = %q, want %q", enc, s)
+		}
+
+		enc, err = b.encode()
+		if err != nil {
+			t.Fatalf("encode() returned unexpected error: %+v", err)
+		}
+		got, err := FromStrWithOptions(enc, WithReferenceTime(referenceTime))
+		if err != nil {
+			t.Fatalf("FromStr(%q) returned unexpected error: %+v", enc, err)
+		}
+
+		want, _ := json.Marshal(b)
+		gotJSON, _ := json.Marshal(got)
+		if diff := cmp.Diff(string(want), string(gotJSON)); diff != "" {
+			t.Errorf("FromStr(encode()) mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+// checkLenient checks that lenient decoding of s, which cannot be decoded
+// strictly, returns a *DecodeError or DecodeErrors holding at least one
+// error.
+func checkLenient(t *testing.T, s string) {
+	t.Helper()
+
+	_, err := FromStrWithOptions(s, WithReferenceTime(referenceTime), WithLenientDecoding())
+	var de *DecodeError
+	var errs DecodeErrors
+	switch {
+	case errors.As(err, &errs):
+		if len(errs) == 0 {
+			t.Fatal("FromStr() returned empty DecodeErrors")
+		}
+	case errors.As(err, &de):
+	default:
+		t.Fatalf("FromStr() returned %v, want *DecodeError or DecodeErrors", err)
+	}
+}
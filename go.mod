@@ -2,4 +2,8 @@ module github.com/jandauz/boarding-pass
 
 go 1.16
 
-require github.com/google/go-cmp v0.5.5
+require (
+	github.com/boombuler/barcode v1.1.0
+	github.com/google/go-cmp v0.5.5
+	github.com/makiuchi-d/gozxing v0.1.1
+)
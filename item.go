@@ -1,7 +1,5 @@
 package bcbp
 
-import "regexp"
-
 // item represents an item in the IATA 729 Bar Coded Boarding Pass specification.
 //
 // An item can belong to one of 3 main categories:
@@ -15,18 +13,68 @@ import "regexp"
 // The length of an item dictates how many characters belong to that field.
 // For example, PassengerName is the second field in a Bar Coded Boarding Pass
 // and is 20 characters long.
+//
+// The version of an item is the version of the IATA 792 specification that
+// introduced the item. Items with a version of 0 are part of every version.
 type item struct {
 	id          itemID
 	description string
 	length      int
 	format      string
-	regex       *regexp.Regexp
+	validator   validator
 	items       []item
+	version     uint
 }
 
-// validate validates s against item.regex.
+// validate reports whether s matches the format of the item.
 func (i item) validate(s string) bool {
-	return i.regex.FindString(s) != ""
+	return i.validator.match(s)
+}
+
+// inLayout reports whether the item is part of the layout of the given
+// version of the IATA 792 specification.
+func (i item) inLayout(version uint) bool {
+	return i.version <= version
+}
+
+// latestVersion is the latest version of the IATA 792 specification that is
+// supported. Fast Track, introduced in version 5, is the only item that is not
+// part of every version from 1 to latestVersion.
+const latestVersion = 8
+
+// layout returns the version of the layout used to process a Bar Coded
+// Boarding Pass encoded using the given version of the IATA 792
+// specification. Versions newer than latestVersion use the latest layout.
+func layout(version uint) uint {
+	if version > latestVersion {
+		return latestVersion
+	}
+	return version
+}
+
+// unique reports whether the item appears only once in a Bar Coded Boarding
+// Pass rather than once for each flight segment.
+func (i item) unique() bool {
+	switch i.id {
+	case formatCode,
+		numberOfLegsEncoded,
+		passengerName,
+		electronicTicketIndicator,
+		beginningOfVersionNumber,
+		versionNumber,
+		fieldSizeOfFollowingStructuredMessageUnique,
+		passengerDescription,
+		sourceOfCheckin,
+		sourceOfBoardingPassIssuance,
+		dateOfIssueOfBoardingPass,
+		documentType,
+		airlineDesignatorOfBoardingPassIssuer,
+		baggageTagLicensePlateNumber,
+		firstNonConsecutiveBaggageTagLicensePlateNumber,
+		secondNonConsecutiveBaggageTagLicensePlateNumber:
+		return true
+	}
+	return false
 }
 
 type itemID uint
@@ -77,6 +125,73 @@ const (
 	securityData
 )
 
+// itemIDs are the identifiers returned by itemID.String.
+var itemIDs = [...]string{
+	formatCode:                   "format_code",
+	numberOfLegsEncoded:          "number_of_legs_encoded",
+	passengerName:                "passenger_name",
+	electronicTicketIndicator:    "electronic_ticket_indicator",
+	operatingCarrierPNRCode:      "operating_carrier_pnr_code",
+	fromCityAirportCode:          "from_city_airport_code",
+	toCityAirportCode:            "to_city_airport_code",
+	operatingCarrierDesignator:   "operating_carrier_designator",
+	flightNumber:                 "flight_number",
+	dateOfFlight:                 "date_of_flight",
+	compartmentCode:              "compartment_code",
+	seatNumber:                   "seat_number",
+	checkinSequenceNumber:        "check_in_sequence_number",
+	passengerStatus:              "passenger_status",
+	fieldSizeOfVariableSizeField: "field_size_of_variable_size_field",
+	beginningOfVersionNumber:     "beginning_of_version_number",
+	versionNumber:                "version_number",
+	fieldSizeOfFollowingStructuredMessageUnique:      "field_size_of_following_structured_message_unique",
+	passengerDescription:                             "passenger_description",
+	sourceOfCheckin:                                  "source_of_check_in",
+	sourceOfBoardingPassIssuance:                     "source_of_boarding_pass_issuance",
+	dateOfIssueOfBoardingPass:                        "date_of_issue_of_boarding_pass",
+	documentType:                                     "document_type",
+	airlineDesignatorOfBoardingPassIssuer:            "airline_designator_of_boarding_pass_issuer",
+	baggageTagLicensePlateNumber:                     "baggage_tag_license_plate_number",
+	firstNonConsecutiveBaggageTagLicensePlateNumber:  "first_non_consecutive_baggage_tag_license_plate_number",
+	secondNonConsecutiveBaggageTagLicensePlateNumber: "second_non_consecutive_baggage_tag_license_plate_number",
+	fieldSizeOfFollowingStructuredMessageRepeated:    "field_size_of_following_structured_message_repeated",
+	airlineNumericCode:                               "airline_numeric_code",
+	documentFormSerialNumber:                         "document_form_serial_number",
+	selecteeIndicator:                                "selectee_indicator",
+	internationalDocumentationVerification:           "international_documentation_verification",
+	marketingCarrierDesignator:                       "marketing_carrier_designator",
+	frequentFlyerAirlineDesignator:                   "frequent_flyer_airline_designator",
+	frequentFlyerNumber:                              "frequent_flyer_number",
+	idadIndicator:                                    "idad_indicator",
+	freeBaggageAllowance:                             "free_baggage_allowance",
+	fastTrack:                                        "fast_track",
+	forIndividualAirlineUse:                          "for_individual_airline_use",
+	beginningOfSecurityData:                          "beginning_of_security_data",
+	typeOfSecurityData:                               "type_of_security_data",
+	lengthOfSecurityData:                             "length_of_security_data",
+	securityData:                                     "security_data",
+}
+
+// String returns the identifier of the item, its name in snake case. Items
+// that hold data are identified by the JSON key of their field, e.g.
+// date_of_flight.
+func (id itemID) String() string {
+	if int(id) < len(itemIDs) {
+		return itemIDs[id]
+	}
+	return ""
+}
+
+// singleLegNumberOfLegsEncoded is the Number of Legs Encoded item of a single
+// leg Bar Coded Boarding Pass.
+var singleLegNumberOfLegsEncoded = item{
+	id:          numberOfLegsEncoded,
+	description: "Number of Legs Encoded",
+	length:      1,
+	format:      `1 for "S" type boarding passes`,
+	validator:   singleLegNumberOfLegsEncodedValidator,
+}
+
 // spec is a graph of items that dictates how a Bar Coded Boarding Pass is
 // processed.
 var spec = []item{
@@ -84,169 +199,169 @@ var spec = []item{
 		id:          formatCode,
 		description: "Format Code",
 		length:      1,
-		format:      `"M"`,
-		regex:       formatCodeRegex,
+		format:      `"M" or "S"`,
+		validator:   formatCodeValidator,
 	},
 	{
 		id:          numberOfLegsEncoded,
 		description: "Number of Legs Encoded",
 		length:      1,
 		format:      "a number between 1 to 4",
-		regex:       numberOfLegsEncodedRegex,
+		validator:   numberOfLegsEncodedValidator,
 	},
 	{
 		id:          passengerName,
 		description: "Passenger Name",
 		length:      20,
 		format:      `20 characters with trailing whitespaces where the last name must be at most 18 characters followed by "/" and an alpha initial`,
-		regex:       passengerNameRegex,
+		validator:   passengerNameValidator,
 	},
 	{
 		id:          electronicTicketIndicator,
 		description: "Electronic Ticket Indicator",
 		length:      1,
 		format:      "E or L",
-		regex:       electronicTicketRegex,
+		validator:   electronicTicketValidator,
 	},
 	{
 		id:          operatingCarrierPNRCode,
 		description: "Operating Carrier PNR Code",
 		length:      7,
 		format:      "7 alphanumeric characters with trailing whitespaces",
-		regex:       operatingCarrierPNRCodeRegex,
+		validator:   operatingCarrierPNRCodeValidator,
 	},
 	{
 		id:          fromCityAirportCode,
 		description: "From City Airport Code",
 		length:      3,
 		format:      "3 alpha characters",
-		regex:       airportCodeRegex,
+		validator:   airportCodeValidator,
 	},
 	{
 		id:          toCityAirportCode,
 		description: "To City Airport Code",
 		length:      3,
 		format:      "3 alpha characters",
-		regex:       airportCodeRegex,
+		validator:   airportCodeValidator,
 	},
 	{
 		id:          operatingCarrierDesignator,
 		description: "Operating Carrier Designator",
 		length:      3,
 		format:      "3 alphanumeric characters with trailing whitespaces",
-		regex:       operatingCarrierDesignatorRegex,
+		validator:   operatingCarrierDesignatorValidator,
 	},
 	{
 		id:          flightNumber,
 		description: "Flight Number",
 		length:      5,
 		format:      "4 digits with leading zeroes followed by an optional alpha suffix or whitespace",
-		regex:       flightNumberRegex,
+		validator:   flightNumberValidator,
 	},
 	{
 		id:          dateOfFlight,
 		description: "Date of Flight (Julian Date)",
 		length:      3,
-		format:      "3 digits with leading zeroes with maximum value of 365 (366 for leap years)",
-		regex:       dateOfFlightRegex,
+		format:      "3 digits with leading zeroes between 001 and 365 (366 for leap years)",
+		validator:   dateOfFlightValidator,
 	},
 	{
 		id:          compartmentCode,
 		description: "Compartment Code",
 		length:      1,
 		format:      "an alpha character",
-		regex:       compartmentCodeRegex,
+		validator:   compartmentCodeValidator,
 	},
 	{
 		id:          seatNumber,
 		description: "Seat Number",
 		length:      4,
 		format:      "3 digits with leading zeroes followed by an alpha",
-		regex:       seatNumberRegex,
+		validator:   seatNumberValidator,
 	},
 	{
 		id:          checkinSequenceNumber,
 		description: "Check-in Sequence Number",
 		length:      5,
 		format:      "4 digits with leading zeroes followed by an optional alpha or whitespace",
-		regex:       checkInSequenceNumberRegex,
+		validator:   checkInSequenceNumberValidator,
 	},
 	{
 		id:          passengerStatus,
 		description: "Passenger Status",
 		length:      1,
 		format:      "an alphanumeric character",
-		regex:       passengerStatusRegex,
+		validator:   passengerStatusValidator,
 	},
 	{
 		id:          fieldSizeOfVariableSizeField,
 		description: "Field Size of variable size field",
 		length:      2,
 		format:      "a hex number with leading zeroes",
-		regex:       hexRegex,
+		validator:   hexValidator,
 		items: []item{
 			{
 				id:          beginningOfVersionNumber,
 				description: "Beginning of version number",
 				length:      1,
 				format:      `">"`,
-				regex:       beginningOfVersionNumberRegex,
+				validator:   beginningOfVersionNumberValidator,
 			},
 			{
 				id:          versionNumber,
 				description: "Version Number",
 				length:      1,
 				format:      "a number between 1 and 8",
-				regex:       versionNumberRegex,
+				validator:   versionNumberValidator,
 			},
 			{
 				id:          fieldSizeOfFollowingStructuredMessageUnique,
 				description: "Field Size of following structured message - unique",
 				length:      2,
 				format:      "a hex number with leading zeroes",
-				regex:       hexRegex,
+				validator:   hexValidator,
 				items: []item{
 					{
 						id:          passengerDescription,
 						description: "Passenger Description",
 						length:      1,
 						format:      "an alphanumeric character",
-						regex:       passengerDescriptionRegex,
+						validator:   passengerDescriptionValidator,
 					},
 					{
 						id:          sourceOfCheckin,
 						description: "Source of check-in",
 						length:      1,
 						format:      "W, K, X, R, M, O, T, V, A, or whitespace",
-						regex:       sourceOfCheckInRegex,
+						validator:   sourceOfCheckInValidator,
 					},
 					{
 						id:          sourceOfBoardingPassIssuance,
 						description: "Source of Boarding Pass Issuance",
 						length:      1,
 						format:      "W, K, X, R, M, O, T, V, or whitespace",
-						regex:       sourceOfBoardingPassIssuanceRegex,
+						validator:   sourceOfBoardingPassIssuanceValidator,
 					},
 					{
 						id:          dateOfIssueOfBoardingPass,
 						description: "Date of Issue of Boarding Pass (Julian Date)",
 						length:      4,
-						format:      "4 digits with leading zeroes with last 3 digits having maximum value of 365 (366 for leap years)",
-						regex:       dateOfIssueOfBoardingPassRegex,
+						format:      "4 digits with leading zeroes with last 3 digits between 001 and 365 (366 for leap years)",
+						validator:   dateOfIssueOfBoardingPassValidator,
 					},
 					{
 						id:          documentType,
 						description: "Document Type",
 						length:      1,
 						format:      "B, I, or whitespace",
-						regex:       documentTypeRegex,
+						validator:   documentTypeValidator,
 					},
 					{
 						id:          airlineDesignatorOfBoardingPassIssuer,
 						description: "Airline Designator of boarding pass issuer",
 						length:      3,
 						format:      "left justified 3 alphanumeric characters with trailing whitespaces",
-						regex:       airlineDesignatorOfBoardingPassIssuerRegex,
+						validator:   airlineDesignatorOfBoardingPassIssuerValidator,
 					},
 					{
 						id:          baggageTagLicensePlateNumber,
@@ -255,8 +370,8 @@ var spec = []item{
 						// IATA 792 spec states that this field is alphanumeric
 						// however the interpretation of the data shows it to be
 						// numeric only.
-						format: "13 digits",
-						regex:  baggageTagLicensePlateNumberRegex,
+						format:    "13 digits",
+						validator: baggageTagLicensePlateNumberValidator,
 					},
 					{
 						id:          firstNonConsecutiveBaggageTagLicensePlateNumber,
@@ -265,8 +380,8 @@ var spec = []item{
 						// IATA 792 spec states that this field is alphanumeric
 						// however the interpretation of the data shows it to be
 						// numeric only.
-						format: "13 digits",
-						regex:  baggageTagLicensePlateNumberRegex,
+						format:    "13 digits",
+						validator: baggageTagLicensePlateNumberValidator,
 					},
 					{
 						id:          secondNonConsecutiveBaggageTagLicensePlateNumber,
@@ -275,8 +390,8 @@ var spec = []item{
 						// IATA 792 spec states that this field is alphanumeric
 						// however the interpretation of the data shows it to be
 						// numeric only.
-						format: "13 numeric characters",
-						regex:  baggageTagLicensePlateNumberRegex,
+						format:    "13 numeric characters",
+						validator: baggageTagLicensePlateNumberValidator,
 					},
 				},
 			},
@@ -285,84 +400,85 @@ var spec = []item{
 				description: "Field Size of following structured message - repeated",
 				length:      2,
 				format:      "a hex number with leading zeroes",
-				regex:       hexRegex,
+				validator:   hexValidator,
 				items: []item{
 					{
 						id:          airlineNumericCode,
 						description: "Airline Numeric Code",
 						length:      3,
 						format:      "3 digits with leading zeroes",
-						regex:       airlineNumericCodeRegex,
+						validator:   airlineNumericCodeValidator,
 					},
 					{
 						id:          documentFormSerialNumber,
 						description: "Document Form/Serial Number",
 						length:      10,
 						format:      "10 alphanumeric characters with leading zeroes",
-						regex:       documentFormSerialNumberRegex,
+						validator:   documentFormSerialNumberValidator,
 					},
 					{
 						id:          selecteeIndicator,
 						description: "Selectee Indicator",
 						length:      1,
 						format:      "0, 1, 2, or whitespace",
-						regex:       selecteeIndicatorRegex,
+						validator:   selecteeIndicatorValidator,
 					},
 					{
 						id:          internationalDocumentationVerification,
 						description: "International Documentation Verification",
 						length:      1,
 						format:      "0, 1, 2, or whitespace",
-						regex:       internationalDocumentationVerificationRegex,
+						validator:   internationalDocumentationVerificationValidator,
 					},
 					{
 						id:          marketingCarrierDesignator,
 						description: "Marketing Carrier Designator",
 						length:      3,
 						format:      "3 alphanumeric characters with trailing whitespaces",
-						regex:       marketingCarrierDesignatorRegex,
+						validator:   marketingCarrierDesignatorValidator,
 					},
 					{
 						id:          frequentFlyerAirlineDesignator,
 						description: "Frequent Flyer Airline Designator",
 						length:      3,
 						format:      "3 alphanumeric characters with trailing whitespaces",
-						regex:       frequentFlyerAirlineDesignatorRegex,
+						validator:   frequentFlyerAirlineDesignatorValidator,
 					},
 					{
 						id:          frequentFlyerNumber,
 						description: "Frequent Flyer Number",
 						length:      16,
 						format:      "16 alphanumeric characters with trailing whitespaces",
-						regex:       frequentFlyerNumberRegex,
+						validator:   frequentFlyerNumberValidator,
 					},
 					{
 						id:          idadIndicator,
 						description: "ID/AD Indicator",
 						length:      1,
 						format:      "an alphanumeric character or whitespace",
-						regex:       idadIndicatorRegex,
+						validator:   idadIndicatorValidator,
 					},
 					{
 						id:          freeBaggageAllowance,
 						description: "Free Baggage Allowance",
 						length:      3,
 						format:      "2 digits with leading zeroes followed by K or L; or 1 digit followed by PC",
-						regex:       freeBaggageAllowanceRegex,
+						validator:   freeBaggageAllowanceValidator,
 					},
 					{
 						id:          fastTrack,
 						description: "Fast Track",
 						length:      1,
 						format:      `Y, N, or " "`,
-						regex:       fastTrackRegex,
+						validator:   fastTrackValidator,
+						version:     5,
 					},
 				},
 			},
 			{
 				id:          forIndividualAirlineUse,
 				description: "For individual airline use",
-				regex:       dotRegex,
+				validator:   anyValidator,
 			},
 		},
 	},
@@ -371,26 +487,26 @@ var spec = []item{
 		description: "Beginning of Security data",
 		length:      1,
 		format:      `"^"`,
-		regex:       beginningOfSecurityDataRegex,
+		validator:   beginningOfSecurityDataValidator,
 	},
 	{
 		id:          typeOfSecurityData,
 		description: "Type of Security data",
 		length:      1,
 		format:      "an alphanumeric character",
-		regex:       typeOfSecurityDataRegex,
+		validator:   typeOfSecurityDataValidator,
 	},
 	{
 		id:          lengthOfSecurityData,
 		description: "Length of Security data",
 		length:      2,
 		format:      "a hex number",
-		regex:       hexRegex,
+		validator:   hexValidator,
 		items: []item{
 			{
 				id:          securityData,
 				description: "Security data",
-				regex:       dotRegex,
+				validator:   anyValidator,
 			},
 		},
 	},
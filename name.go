@@ -0,0 +1,199 @@
+package bcbp
+
+import "strings"
+
+// passengerNameLen is the length of the Passenger Name item.
+const passengerNameLen = 20
+
+// truncatedSurnameLen is the length a surname is truncated to when there is
+// not enough space for the given name.
+const truncatedSurnameLen = 18
+
+// PassengerName is the name of a passenger encoded in the following format:
+//   SURNAME/GIVEN_NAMES TITLE
+// The title is optional.
+type PassengerName string
+
+// Title is the title of a passenger that may follow the given names.
+type Title string
+
+const (
+	TitleMr     Title = "MR"
+	TitleMrs    Title = "MRS"
+	TitleMs     Title = "MS"
+	TitleMaster Title = "MSTR"
+	TitleDr     Title = "DR"
+)
+
+// titles are the titles recognized by PassengerName.Title.
+var titles = []Title{TitleMr, TitleMrs, TitleMs, TitleMaster, TitleDr}
+
+// Valid reports whether n matches the format of the Passenger Name item.
+func (n PassengerName) Valid() bool {
+	return len(n) <= passengerNameLen && passengerNameValidator.match(string(n))
+}
+
+// split returns the surname and the given names, including the title, of n.
+func (n PassengerName) split() (string, string) {
+	s := strings.TrimSpace(string(n))
+	i := strings.Index(s, "/")
+	if i < 0 {
+		return s, ""
+	}
+	return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+}
+
+// Surname returns the surname of n. It may be truncated, see
+// SurnameTruncated.
+func (n PassengerName) Surname() string {
+	surname, _ := n.split()
+	return surname
+}
+
+// GivenNames returns the given names of n without the title.
+func (n PassengerName) GivenNames() string {
+	_, given := n.split()
+	if i := strings.LastIndex(given, " "); i >= 0 && isTitle(given[i+1:]) {
+		return strings.TrimSpace(given[:i])
+	}
+	return given
+}
+
+// Title returns the title of n. The title must be separated from the given
+// names by a whitespace. An empty Title is returned if n does not have a
+// title.
+func (n PassengerName) Title() Title {
+	_, given := n.split()
+	if i := strings.LastIndex(given, " "); i >= 0 && isTitle(given[i+1:]) {
+		return Title(strings.ToUpper(given[i+1:]))
+	}
+	return ""
+}
+
+// isTitle reports whether s is one of titles.
+func isTitle(s string) bool {
+	for _, t := range titles {
+		if strings.EqualFold(s, string(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Truncated reports whether n may be truncated. That is, n uses every
+// character of the Passenger Name item.
+func (n PassengerName) Truncated() bool {
+	return len(strings.TrimSpace(string(n))) == passengerNameLen
+}
+
+// SurnameTruncated reports whether the surname of n may be truncated. If
+// there is not enough space for the given name then the surname is truncated
+// at the 18th character followed by a "/" and one alpha initial.
+func (n PassengerName) SurnameTruncated() bool {
+	surname, given := n.split()
+	return len(surname) == truncatedSurnameLen && len(given) == 1
+}
+
+// MatchMRZ reports whether n is likely the same name as mrz, the name field
+// of the Machine Readable Zone of a passport. For example:
+//   DESMARAIS<<LUC<PIERRE<<<<<<<<<
+//
+// Names are compared ignoring case, whitespaces, and filler characters.
+// Truncated names match if they are a prefix of the name in mrz. A single
+// character difference, e.g. due to transliteration, is tolerated for names
+// of at least 4 characters. The given names of n match if they are empty, an
+// initial, or any of the given names in mrz.
+func (n PassengerName) MatchMRZ(mrz string) bool {
+	mrzSurname, mrzGiven := mrz, ""
+	if i := strings.Index(mrz, "<<"); i >= 0 {
+		mrzSurname, mrzGiven = mrz[:i], mrz[i+2:]
+	}
+
+	surname := compactName(n.Surname())
+	if !matchName(surname, compactName(mrzSurname), n.SurnameTruncated()) {
+		return false
+	}
+
+	given := compactName(n.GivenNames())
+	if given == "" {
+		return true
+	}
+
+	mrzNames := strings.FieldsFunc(strings.ToUpper(mrzGiven), func(r rune) bool {
+		return r == '<' || r == ' '
+	})
+	if len(mrzNames) == 0 {
+		return false
+	}
+
+	// The given name is an initial.
+	if len(given) == 1 {
+		return given[0] == mrzNames[0][0]
+	}
+
+	if matchName(given, compactName(mrzGiven), n.Truncated()) {
+		return true
+	}
+	for _, name := range mrzNames {
+		if matchName(given, name, n.Truncated()) {
+			return true
+		}
+	}
+	return false
+}
+
+// compactName returns s in upper case with every character that is not a
+// letter removed.
+func compactName(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if r >= 'A' && r <= 'Z' {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// minFuzzyNameLen is the minimum length of a name for a single character
+// difference to be tolerated.
+const minFuzzyNameLen = 4
+
+// matchName reports whether name matches want. If truncated, name only needs
+// to match the beginning of want.
+func matchName(name, want string, truncated bool) bool {
+	if truncated && len(want) > len(name) {
+		want = want[:len(name)]
+	}
+	if name == want {
+		return true
+	}
+	return len(name) >= minFuzzyNameLen && editDistance(name, want) <= 1
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,130 @@
+package bcbp
+
+import "testing"
+
+func TestPassengerName(t *testing.T) {
+	tests := []struct {
+		name             PassengerName
+		surname          string
+		givenNames       string
+		title            Title
+		truncated        bool
+		surnameTruncated bool
+	}{
+		{
+			name:       "DESMARAIS/LUC       ",
+			surname:    "DESMARAIS",
+			givenNames: "LUC",
+		},
+		{
+			name:       "DESMARAIS/LUC MR",
+			surname:    "DESMARAIS",
+			givenNames: "LUC",
+			title:      TitleMr,
+		},
+		{
+			name:       "SMITH/JON PAUL MSTR",
+			surname:    "SMITH",
+			givenNames: "JON PAUL",
+			title:      TitleMaster,
+		},
+		{
+			name:       "SMITH/MRJOHN",
+			surname:    "SMITH",
+			givenNames: "MRJOHN",
+		},
+		{
+			name:    "SMITH",
+			surname: "SMITH",
+		},
+		{
+			name:       "DESMARAIS/LUCPIERRES",
+			surname:    "DESMARAIS",
+			givenNames: "LUCPIERRES",
+			truncated:  true,
+		},
+		{
+			name:       "WOLFESCHLEGELSTEI/H",
+			surname:    "WOLFESCHLEGELSTEI",
+			givenNames: "H",
+		},
+		{
+			name:             "WOLFESCHLEGELSTEIN/H",
+			surname:          "WOLFESCHLEGELSTEIN",
+			givenNames:       "H",
+			truncated:        true,
+			surnameTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			if got := tt.name.Surname(); got != tt.surname {
+				t.Errorf("Surname() = %q, want %q", got, tt.surname)
+			}
+			if got := tt.name.GivenNames(); got != tt.givenNames {
+				t.Errorf("GivenNames() = %q, want %q", got, tt.givenNames)
+			}
+			if got := tt.name.Title(); got != tt.title {
+				t.Errorf("Title() = %q, want %q", got, tt.title)
+			}
+			if got := tt.name.Truncated(); got != tt.truncated {
+				t.Errorf("Truncated() = %t, want %t", got, tt.truncated)
+			}
+			if got := tt.name.SurnameTruncated(); got != tt.surnameTruncated {
+				t.Errorf("SurnameTruncated() = %t, want %t", got, tt.surnameTruncated)
+			}
+		})
+	}
+}
+
+func TestPassengerName_Valid(t *testing.T) {
+	tests := []struct {
+		name PassengerName
+		want bool
+	}{
+		{name: "DESMARAIS/LUC       ", want: true},
+		{name: "DESMARAIS/LUC", want: true},
+		{name: "DESMARAIS/LUC PIERRE MR", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			if got := tt.name.Valid(); got != tt.want {
+				t.Errorf("Valid() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPassengerName_MatchMRZ(t *testing.T) {
+	tests := []struct {
+		name PassengerName
+		mrz  string
+		want bool
+	}{
+		{name: "DESMARAIS/LUC", mrz: "DESMARAIS<<LUC<PIERRE<<<<<<<<<", want: true},
+		{name: "DESMARAIS/LUC MR", mrz: "DESMARAIS<<LUC<PIERRE<<<<<<<<<", want: true},
+		{name: "DESMARAIS/PIERRE", mrz: "DESMARAIS<<LUC<PIERRE<<<<<<<<<", want: true},
+		{name: "DESMARAIS/LUCPIERRE", mrz: "DESMARAIS<<LUC<PIERRE<<<<<<<<<", want: true},
+		{name: "DESMARAIS/L", mrz: "DESMARAIS<<LUC<PIERRE<<<<<<<<<", want: true},
+		{name: "DESMARAIS", mrz: "DESMARAIS<<LUC<PIERRE<<<<<<<<<", want: true},
+		{name: "DES MARAIS/LUC", mrz: "DES<MARAIS<<LUC<<<<<<<<<<<<<<<", want: true},
+		{name: "MUELLER/ANNA", mrz: "MULLER<<ANNA<<<<<<<<<<<<<<<<<<", want: true},
+		{name: "DESMARAIS/LUCPIERRES", mrz: "DESMARAIS<<LUCPIERRESTEPHANE<<", want: true},
+		{name: "WOLFESCHLEGELSTEIN/H", mrz: "WOLFESCHLEGELSTEINHAUSEN<<HUBERT<<<", want: true},
+		{name: "DESMARAIS/ANNE", mrz: "DESMARAIS<<LUC<PIERRE<<<<<<<<<", want: false},
+		{name: "DESMARAIS/P", mrz: "DESMARAIS<<LUC<PIERRE<<<<<<<<<", want: false},
+		{name: "SMITH/LUC", mrz: "DESMARAIS<<LUC<PIERRE<<<<<<<<<", want: false},
+		{name: "LI/WEI", mrz: "LU<<WEI<<<<<<<<<<<<<<<<<<<<<<<", want: false},
+		{name: "DESMARAIS/LUC", mrz: "DESMARAIS<<<<<<<<<<<<<<<<<<<<<", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.name)+" "+tt.mrz, func(t *testing.T) {
+			if got := tt.name.MatchMRZ(tt.mrz); got != tt.want {
+				t.Errorf("MatchMRZ() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package bcbp
+
+import "time"
+
+// Option configures how a Bar Coded Boarding Pass is decoded.
+type Option func(*options)
+
+// options holds the configuration used while decoding a Bar Coded Boarding
+// Pass.
+type options struct {
+	// referenceTime is the time used to resolve the year of Julian dates.
+	referenceTime time.Time
+
+	// lenient determines whether decoding continues past invalid items.
+	lenient bool
+
+	// referenceData is used to validate airport and airline codes. Codes
+	// are not validated if it is nil.
+	referenceData ReferenceData
+
+	// copy determines whether the data is copied before decoding so that
+	// the fields of the BCBP do not reference it.
+	copy bool
+
+	// fields determines whether every decoded item is recorded. It is only
+	// set by BCBP.Fields.
+	fields bool
+}
+
+// defaultOptions returns the options used unless overridden.
+func defaultOptions() options {
+	return options{
+		referenceTime: time.Now(),
+	}
+}
+
+// newOptions returns the default options with opts applied.
+func newOptions(opts ...Option) options {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithReferenceTime sets the time used to resolve the year of the Julian dates
+// encoded in a Bar Coded Boarding Pass. It defaults to time.Now().
+//
+// Date of Flight does not encode a year. The year that places the date
+// closest to t is used. For example, a flight on day 364 decoded with a
+// reference time of January 2, 2022 is resolved to December 30, 2021.
+//
+// Date of Issue of Boarding Pass only encodes the last digit of the year.
+// The year ending in that digit that places the date closest to t is used.
+func WithReferenceTime(t time.Time) Option {
+	return func(o *options) {
+		o.referenceTime = t
+	}
+}
+
+// WithLenientDecoding continues decoding past items whose data does not match
+// their format instead of stopping at the first error. Invalid items are left
+// empty and every error is returned as DecodeErrors.
+//
+// Errors that prevent the remainder of the data from being decoded, such as
+// an invalid sub-section length, still stop decoding.
+func WithLenientDecoding() Option {
+	return func(o *options) {
+		o.lenient = true
+	}
+}
+
+// WithReferenceData validates the airport and airline codes of every leg
+// against r. A *DecodeError of type ErrUnknownCode is returned for codes that
+// are unknown by r. The following items are validated:
+//   From City Airport Code
+//   To City Airport Code
+//   Operating carrier Designator
+//   Airline Numeric Code
+//
+// The airports and operating carrier found are set on each Leg as
+// FromAirport, ToAirport, and OperatingCarrier.
+func WithReferenceData(r ReferenceData) Option {
+	return func(o *options) {
+		o.referenceData = r
+	}
+}
+
+// WithCopy copies the Bar Coded Boarding Pass data before decoding it. By
+// default, the fields of the decoded BCBP reference the data, which keeps all
+// of it in memory for as long as any field is used. This matters when the
+// data is part of a larger buffer, or when decoding a []byte using
+// DecodeBytesInto that is later modified.
+func WithCopy() Option {
+	return func(o *options) {
+		o.copy = true
+	}
+}
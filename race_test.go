@@ -0,0 +1,8 @@
+//go:build race
+// +build race
+
+package bcbp
+
+// raceEnabled reports whether the race detector is enabled. The race detector
+// allocates which makes allocation counts meaningless.
+const raceEnabled = true
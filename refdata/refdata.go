@@ -0,0 +1,121 @@
+// Package refdata provides airport and airline reference data used to
+// validate and enrich IATA 792 Bar Coded Boarding Passes.
+//
+// No dataset is embedded: the codes assigned by IATA and ICAO change over
+// time and their complete lists are licensed by IATA and ICAO. A dataset is
+// loaded from CSV files using Load.
+package refdata
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jandauz/boarding-pass"
+)
+
+// Data is a set of airports and airlines. It implements bcbp.ReferenceData.
+// Codes are looked up ignoring case and surrounding whitespaces.
+type Data struct {
+	airports map[string]bcbp.Airport
+	// airlines holds airlines by both their IATA and ICAO designators.
+	airlines map[string]bcbp.Airline
+	numeric  map[string]bcbp.Airline
+}
+
+var _ bcbp.ReferenceData = &Data{}
+
+// Load reads a dataset from CSV files. Both files start with a header row.
+// The columns of airports are:
+//   code,name,city,country,time_zone
+// The columns of airlines are:
+//   designator,icao_designator,numeric_code,name
+func Load(airports, airlines io.Reader) (*Data, error) {
+	d := &Data{
+		airports: make(map[string]bcbp.Airport),
+		airlines: make(map[string]bcbp.Airline),
+		numeric:  make(map[string]bcbp.Airline),
+	}
+
+	err := readCSV(airports, 5, func(r []string) {
+		a := bcbp.Airport{
+			Code:     normalize(r[0]),
+			Name:     r[1],
+			City:     r[2],
+			Country:  r[3],
+			TimeZone: r[4],
+		}
+		d.airports[a.Code] = a
+	})
+	if err != nil {
+		return nil, fmt.Errorf("refdata: cannot read airports: %w", err)
+	}
+
+	err = readCSV(airlines, 4, func(r []string) {
+		a := bcbp.Airline{
+			Designator:     normalize(r[0]),
+			ICAODesignator: normalize(r[1]),
+			NumericCode:    strings.TrimSpace(r[2]),
+			Name:           r[3],
+		}
+		d.airlines[a.Designator] = a
+		if a.ICAODesignator != "" {
+			d.airlines[a.ICAODesignator] = a
+		}
+		if a.NumericCode != "" {
+			d.numeric[a.NumericCode] = a
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("refdata: cannot read airlines: %w", err)
+	}
+	return d, nil
+}
+
+// readCSV reads the records of r, skipping the header row, and calls fn for
+// each one. Every record must have n fields.
+func readCSV(r io.Reader, n int, fn func(record []string)) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = n
+
+	if _, err := cr.Read(); err != nil {
+		return err
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(record)
+	}
+}
+
+// normalize returns code in upper case without surrounding whitespaces.
+func normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Airport returns the airport with the IATA code. false is returned if the
+// airport is unknown.
+func (d *Data) Airport(code string) (bcbp.Airport, bool) {
+	a, ok := d.airports[normalize(code)]
+	return a, ok
+}
+
+// Airline returns the airline with the 2 character IATA designator or the 3
+// letter ICAO designator. false is returned if the airline is unknown.
+func (d *Data) Airline(designator string) (bcbp.Airline, bool) {
+	a, ok := d.airlines[normalize(designator)]
+	return a, ok
+}
+
+// AirlineByNumericCode returns the airline with the IATA numeric code. false
+// is returned if the airline is unknown.
+func (d *Data) AirlineByNumericCode(code string) (bcbp.Airline, bool) {
+	a, ok := d.numeric[strings.TrimSpace(code)]
+	return a, ok
+}
@@ -0,0 +1,152 @@
+package refdata
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jandauz/boarding-pass"
+)
+
+const mandatory = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100"
+
+// load loads the sample dataset of major airports and airlines held in
+// testdata.
+func load(t *testing.T) *Data {
+	t.Helper()
+
+	airports, err := os.Open("testdata/airports.csv")
+	if err != nil {
+		t.Fatalf("failed opening airports: %v", err)
+	}
+	defer airports.Close()
+
+	airlines, err := os.Open("testdata/airlines.csv")
+	if err != nil {
+		t.Fatalf("failed opening airlines: %v", err)
+	}
+	defer airlines.Close()
+
+	d, err := Load(airports, airlines)
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %+v", err)
+	}
+	return d
+}
+
+func TestLoad(t *testing.T) {
+	d := load(t)
+
+	a, ok := d.Airport("yul")
+	if !ok {
+		t.Fatal("Airport() = false, want true")
+	}
+	want := bcbp.Airport{
+		Code:     "YUL",
+		Name:     "Montreal-Trudeau International Airport",
+		City:     "Montreal",
+		Country:  "CA",
+		TimeZone: "America/Toronto",
+	}
+	if diff := cmp.Diff(want, a); diff != "" {
+		t.Errorf("Airport() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, ok := d.Airport("XXX"); ok {
+		t.Error("Airport() = true for XXX, want false")
+	}
+	if a, ok := d.Airline("AC "); !ok || a.NumericCode != "014" {
+		t.Errorf("Airline() = %+v, %t, want numeric code 014", a, ok)
+	}
+	if a, ok := d.Airline("aca"); !ok || a.Designator != "AC" {
+		t.Errorf("Airline() = %+v, %t, want designator AC", a, ok)
+	}
+	if a, ok := d.AirlineByNumericCode("220"); !ok || a.Designator != "LH" {
+		t.Errorf("AirlineByNumericCode() = %+v, %t, want designator LH", a, ok)
+	}
+}
+
+func TestLoad_TimeZones(t *testing.T) {
+	for _, a := range load(t).airports {
+		if _, err := a.Location(); err != nil {
+			t.Errorf("%s: Location() returned unexpected error: %+v", a.Code, err)
+		}
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	airlines := "designator,icao_designator,numeric_code,name\nAC,ACA,014,Air Canada\n"
+	if _, err := Load(strings.NewReader("code,name\nYUL,Montreal\n"), strings.NewReader(airlines)); err == nil {
+		t.Error("Load() = nil: expected error")
+	}
+}
+
+func TestWithReferenceData(t *testing.T) {
+	data, err := os.ReadFile("../testdata/full_multi.input")
+	if err != nil {
+		t.Fatalf("failed reading .input file: %v", err)
+	}
+
+	b, err := bcbp.FromStrWithOptions(string(data), bcbp.WithReferenceData(load(t)))
+	if err != nil {
+		t.Fatalf("FromStrWithOptions() returned unexpected error: %+v", err)
+	}
+	if b.Legs[0].FromAirport == nil || b.Legs[0].FromAirport.City != "Montreal" {
+		t.Errorf("FromAirport = %+v, want Montreal", b.Legs[0].FromAirport)
+	}
+	if b.Legs[1].ToAirport == nil || b.Legs[1].ToAirport.TimeZone != "Europe/Zurich" {
+		t.Errorf("ToAirport = %+v, want Europe/Zurich", b.Legs[1].ToAirport)
+	}
+	if b.Legs[1].OperatingCarrier == nil || b.Legs[1].OperatingCarrier.Name != "Lufthansa" {
+		t.Errorf("OperatingCarrier = %+v, want Lufthansa", b.Legs[1].OperatingCarrier)
+	}
+
+	// Enriched data is not encoded.
+	got, err := b.Encode()
+	if err != nil {
+		t.Fatalf("Encode() returned unexpected error: %+v", err)
+	}
+	if got != string(data) {
+		t.Errorf("Encode() = %q, want %q", got, string(data))
+	}
+}
+
+func TestWithReferenceData_UnknownCode(t *testing.T) {
+	s := strings.Replace(mandatory, "YULFRAAC ", "YULXXXAC ", 1)
+
+	_, err := bcbp.FromStrWithOptions(s, bcbp.WithReferenceData(load(t)))
+	var de *bcbp.DecodeError
+	if !errors.As(err, &de) || de.Type != bcbp.ErrUnknownCode {
+		t.Fatalf("FromStrWithOptions() = %v, want %s", err, bcbp.ErrUnknownCode)
+	}
+	if de.Item != "To City Airport Code" {
+		t.Errorf("Item = %q, want %q", de.Item, "To City Airport Code")
+	}
+
+	// Lenient decoding leaves the unknown code empty.
+	b, err := bcbp.FromStrWithOptions(s, bcbp.WithReferenceData(load(t)), bcbp.WithLenientDecoding())
+	var errs bcbp.DecodeErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("FromStrWithOptions() = %v, want 1 error", err)
+	}
+	if b.Legs[0].ToCityAirportCode != "" || b.Legs[0].ToAirport != nil {
+		t.Errorf("ToCityAirportCode = %q, ToAirport = %+v, want empty", b.Legs[0].ToCityAirportCode, b.Legs[0].ToAirport)
+	}
+	if b.Legs[0].FlightNumber != "0834" {
+		t.Errorf("FlightNumber = %q, want %q", b.Legs[0].FlightNumber, "0834")
+	}
+}
+
+func TestWithReferenceData_ICAODesignator(t *testing.T) {
+	s := strings.Replace(mandatory, "YULFRAAC ", "YULFRAACA", 1)
+
+	b, err := bcbp.FromStrWithOptions(s, bcbp.WithReferenceData(load(t)))
+	if err != nil {
+		t.Fatalf("FromStrWithOptions() returned unexpected error: %+v", err)
+	}
+	if b.Legs[0].OperatingCarrier == nil || b.Legs[0].OperatingCarrier.Designator != "AC" {
+		t.Errorf("OperatingCarrier = %+v, want Air Canada", b.Legs[0].OperatingCarrier)
+	}
+}
@@ -0,0 +1,97 @@
+package bcbp
+
+import "time"
+
+// Airport is an airport identified by its IATA code.
+type Airport struct {
+	// Code is the 3 letter IATA code of the airport, e.g. YUL.
+	Code string `json:"code"`
+
+	// Name is the name of the airport.
+	Name string `json:"name"`
+
+	// City is the city served by the airport.
+	City string `json:"city"`
+
+	// Country is the ISO 3166-1 alpha-2 code of the country of the airport.
+	Country string `json:"country"`
+
+	// TimeZone is the IANA Time Zone database name of the time zone of the
+	// airport, e.g. America/Toronto.
+	TimeZone string `json:"time_zone"`
+}
+
+// Location returns the time zone of a as a *time.Location.
+func (a Airport) Location() (*time.Location, error) {
+	return time.LoadLocation(a.TimeZone)
+}
+
+// Airline is an airline identified by its IATA designator.
+type Airline struct {
+	// Designator is the 2 character IATA designator of the airline, e.g. AC.
+	Designator string `json:"designator"`
+
+	// ICAODesignator is the 3 letter ICAO designator of the airline, e.g.
+	// ACA. Bar Coded Boarding Passes may use it as the airline designator.
+	ICAODesignator string `json:"icao_designator"`
+
+	// NumericCode is the 3 digit IATA numeric code, also known as the
+	// accounting code or ticket prefix, of the airline, e.g. 014.
+	NumericCode string `json:"numeric_code"`
+
+	// Name is the name of the airline.
+	Name string `json:"name"`
+}
+
+// ReferenceData looks up airports and airlines by their codes. The refdata
+// package provides an implementation backed by CSV files.
+type ReferenceData interface {
+	// Airport returns the airport with the IATA code. false is returned if
+	// the airport is unknown.
+	Airport(code string) (Airport, bool)
+
+	// Airline returns the airline with the 2 character IATA designator or
+	// the 3 letter ICAO designator. false is returned if the airline is
+	// unknown.
+	Airline(designator string) (Airline, bool)
+
+	// AirlineByNumericCode returns the airline with the IATA numeric code.
+	// false is returned if the airline is unknown.
+	AirlineByNumericCode(code string) (Airline, bool)
+}
+
+// lookup looks up val, the value of item, in the reference data. The airport
+// or airline found is set on the leg. false is returned if val is not known
+// by the reference data. Empty values and items that do not hold a code are
+// always known.
+func (b *BCBP) lookup(item item, leg int, val string) bool {
+	if val == "" {
+		return true
+	}
+
+	ref := b.opts.referenceData
+	switch item.id {
+	case fromCityAirportCode:
+		a, ok := ref.Airport(val)
+		if ok {
+			b.Legs[leg].FromAirport = &a
+		}
+		return ok
+	case toCityAirportCode:
+		a, ok := ref.Airport(val)
+		if ok {
+			b.Legs[leg].ToAirport = &a
+		}
+		return ok
+	case operatingCarrierDesignator:
+		a, ok := ref.Airline(val)
+		if ok {
+			b.Legs[leg].OperatingCarrier = &a
+		}
+		return ok
+	case airlineNumericCode:
+		_, ok := ref.AirlineByNumericCode(val)
+		return ok
+	}
+	return true
+}
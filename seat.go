@@ -0,0 +1,177 @@
+package bcbp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Seat is the seat assigned to a passenger. It is either 3 digits with
+// leading zeroes followed by an alpha, e.g. 001A, or one of SeatInfant,
+// SeatGate, and SeatStandby.
+type Seat string
+
+const (
+	// SeatInfant is the seat of an infant without a seat of their own.
+	SeatInfant Seat = "INF"
+
+	// SeatGate is the seat of a passenger whose seat is assigned at the gate.
+	SeatGate Seat = "GATE"
+
+	// SeatStandby is the seat of a standby passenger.
+	SeatStandby Seat = "STBY"
+)
+
+// maxSeatRow is the highest row number that can be encoded in a Seat.
+const maxSeatRow = 999
+
+// NewSeat returns the Seat at row and letter, e.g. NewSeat(1, 'A') returns
+// 001A. An error is returned if row is not between 1 and 999 or letter is not
+// an alpha.
+func NewSeat(row int, letter byte) (Seat, error) {
+	if row < 1 || row > maxSeatRow {
+		return "", fmt.Errorf("bcbp: seat row %d must be between 1 and %d", row, maxSeatRow)
+	}
+	if !isAlpha(letter) {
+		return "", fmt.Errorf("bcbp: seat letter %q must be an alpha", letter)
+	}
+	return Seat(fmt.Sprintf("%03d%c", row, upper(letter))), nil
+}
+
+// Valid reports whether s matches the format of the Seat Number item.
+func (s Seat) Valid() bool {
+	return s.Assigned() || s.Infant() || s.Gate() || s.Standby()
+}
+
+// Assigned reports whether s is a row and letter as opposed to one of the
+// special values.
+func (s Seat) Assigned() bool {
+	_, _, ok := s.parse()
+	return ok
+}
+
+// Row returns the row number of s. 0 is returned if s is not Assigned.
+func (s Seat) Row() int {
+	row, _, _ := s.parse()
+	return row
+}
+
+// Letter returns the upper case letter of the seat within its row. 0 is
+// returned if s is not Assigned.
+func (s Seat) Letter() byte {
+	_, letter, _ := s.parse()
+	return letter
+}
+
+// Infant reports whether s is SeatInfant.
+func (s Seat) Infant() bool {
+	return s.is(SeatInfant)
+}
+
+// Gate reports whether s is SeatGate.
+func (s Seat) Gate() bool {
+	return s.is(SeatGate)
+}
+
+// Standby reports whether s is SeatStandby.
+func (s Seat) Standby() bool {
+	return s.is(SeatStandby)
+}
+
+// is reports whether s is the special value v ignoring case and whitespaces.
+func (s Seat) is(v Seat) bool {
+	return strings.EqualFold(strings.TrimSpace(string(s)), string(v))
+}
+
+// parse returns the row and the upper case letter of s. ok is false if s is
+// not 3 digits followed by an alpha.
+func (s Seat) parse() (row int, letter byte, ok bool) {
+	if len(s) != 4 || !isAlpha(s[3]) {
+		return 0, 0, false
+	}
+	for i := 0; i < 3; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, 0, false
+		}
+		row = row*10 + int(s[i]-'0')
+	}
+	return row, upper(s[3]), true
+}
+
+// Compare returns -1, 0, or +1 depending on whether s sorts before, the same
+// as, or after o.
+//
+// Assigned seats sort first by row and then by letter. They are followed by
+// SeatGate, SeatStandby, and SeatInfant in that order. Invalid seats sort
+// last, ordered lexically.
+func (s Seat) Compare(o Seat) int {
+	if r1, r2 := s.rank(), o.rank(); r1 != r2 {
+		return compareInt(r1, r2)
+	}
+
+	switch {
+	case s.Assigned():
+		if c := compareInt(s.Row(), o.Row()); c != 0 {
+			return c
+		}
+		return compareInt(int(s.Letter()), int(o.Letter()))
+	case !s.Valid():
+		return strings.Compare(string(s), string(o))
+	}
+	return 0
+}
+
+// rank returns the position of the kind of s in the order used by Compare.
+func (s Seat) rank() int {
+	switch {
+	case s.Assigned():
+		return 0
+	case s.Gate():
+		return 1
+	case s.Standby():
+		return 2
+	case s.Infant():
+		return 3
+	}
+	return 4
+}
+
+// Format returns s in a human readable format. The row number of an Assigned
+// seat is formatted without leading zeroes, e.g. 001A is formatted as 1A.
+// Special values are formatted in upper case, and invalid seats are returned
+// as is.
+func (s Seat) Format() string {
+	row, letter, ok := s.parse()
+	switch {
+	case ok:
+		return strconv.Itoa(row) + string(letter)
+	case s.Valid():
+		return strings.ToUpper(strings.TrimSpace(string(s)))
+	}
+	return string(s)
+}
+
+// compareInt returns -1, 0, or +1 depending on whether a is less than, equal
+// to, or greater than b.
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// isAlpha reports whether c is an ASCII letter.
+func isAlpha(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// upper returns the upper case of the ASCII letter c.
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
@@ -0,0 +1,120 @@
+package bcbp
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSeat(t *testing.T) {
+	tests := []struct {
+		seat     Seat
+		valid    bool
+		assigned bool
+		row      int
+		letter   byte
+		infant   bool
+		gate     bool
+		standby  bool
+		format   string
+	}{
+		{seat: "001A", valid: true, assigned: true, row: 1, letter: 'A', format: "1A"},
+		{seat: "034k", valid: true, assigned: true, row: 34, letter: 'K', format: "34K"},
+		{seat: "INF", valid: true, infant: true, format: "INF"},
+		{seat: "inf ", valid: true, infant: true, format: "INF"},
+		{seat: "GATE", valid: true, gate: true, format: "GATE"},
+		{seat: "STBY", valid: true, standby: true, format: "STBY"},
+		{seat: "+01A", format: "+01A"},
+		{seat: "01A", format: "01A"},
+		{seat: "", format: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.seat), func(t *testing.T) {
+			if got := tt.seat.Valid(); got != tt.valid {
+				t.Errorf("Valid() = %t, want %t", got, tt.valid)
+			}
+			if got := tt.seat.Assigned(); got != tt.assigned {
+				t.Errorf("Assigned() = %t, want %t", got, tt.assigned)
+			}
+			if got := tt.seat.Row(); got != tt.row {
+				t.Errorf("Row() = %d, want %d", got, tt.row)
+			}
+			if got := tt.seat.Letter(); got != tt.letter {
+				t.Errorf("Letter() = %q, want %q", got, tt.letter)
+			}
+			if got := tt.seat.Infant(); got != tt.infant {
+				t.Errorf("Infant() = %t, want %t", got, tt.infant)
+			}
+			if got := tt.seat.Gate(); got != tt.gate {
+				t.Errorf("Gate() = %t, want %t", got, tt.gate)
+			}
+			if got := tt.seat.Standby(); got != tt.standby {
+				t.Errorf("Standby() = %t, want %t", got, tt.standby)
+			}
+			if got := tt.seat.Format(); got != tt.format {
+				t.Errorf("Format() = %q, want %q", got, tt.format)
+			}
+		})
+	}
+}
+
+func TestNewSeat(t *testing.T) {
+	got, err := NewSeat(7, 'c')
+	if err != nil {
+		t.Fatalf("NewSeat() returned unexpected error: %+v", err)
+	}
+	if want := Seat("007C"); got != want {
+		t.Errorf("NewSeat() = %q, want %q", got, want)
+	}
+
+	for _, tt := range []struct {
+		row    int
+		letter byte
+	}{
+		{row: 0, letter: 'A'},
+		{row: 1000, letter: 'A'},
+		{row: 1, letter: '1'},
+	} {
+		if _, err := NewSeat(tt.row, tt.letter); err == nil {
+			t.Errorf("NewSeat(%d, %q) = nil: expected error", tt.row, tt.letter)
+		}
+	}
+}
+
+func TestSeat_Compare(t *testing.T) {
+	seats := []Seat{"INF", "012C", "STBY", "X", "002B", "GATE", "012A", "002b", "A"}
+	sort.SliceStable(seats, func(i, j int) bool {
+		return seats[i].Compare(seats[j]) < 0
+	})
+
+	want := []Seat{"002B", "002b", "012A", "012C", "GATE", "STBY", "INF", "A", "X"}
+	if diff := cmp.Diff(want, seats); diff != "" {
+		t.Errorf("Compare() order mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFromStr_SpecialSeat(t *testing.T) {
+	for _, seat := range []Seat{SeatInfant, SeatGate, SeatStandby} {
+		t.Run(string(seat), func(t *testing.T) {
+			s := "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J" + fmt.Sprintf("%-4s", seat) + "0025 100"
+			b, err := FromStr(s)
+			if err != nil {
+				t.Fatalf("FromStr() returned unexpected error: %+v", err)
+			}
+			if got := b.Legs[0].SeatNumber; got != seat {
+				t.Errorf("SeatNumber = %q, want %q", got, seat)
+			}
+
+			got, err := b.Encode()
+			if err != nil {
+				t.Fatalf("Encode() returned unexpected error: %+v", err)
+			}
+			if got != s {
+				t.Errorf("Encode() = %q, want %q", got, s)
+			}
+		})
+	}
+}
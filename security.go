@@ -0,0 +1,238 @@
+package bcbp
+
+import (
+	"crypto"
+	"crypto/dsa" //nolint:staticcheck // DSA is recommended by IATA 792.
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec // SHA-1 is used by DSA as recommended by IATA 792.
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"math/big"
+	"strings"
+)
+
+// KeyID identifies the public key used to verify a Bar Coded Boarding Pass.
+// Airlines publish a public key for each type of security data they use.
+type KeyID struct {
+	// Issuer is the airline designator of the boarding pass issuer.
+	Issuer string
+
+	// TypeOfSecurityData is the type of security used on the barcode.
+	TypeOfSecurityData string
+}
+
+// KeyStore provides the public keys used to verify Bar Coded Boarding
+// Passes.
+type KeyStore interface {
+	// PublicKey returns the public key identified by id. It returns false
+	// if there is no public key for id.
+	PublicKey(id KeyID) (crypto.PublicKey, bool)
+}
+
+// KeyMap is a KeyStore backed by a map.
+type KeyMap map[KeyID]crypto.PublicKey
+
+// PublicKey implements the KeyStore interface.
+func (m KeyMap) PublicKey(id KeyID) (crypto.PublicKey, bool) {
+	pub, ok := m[id]
+	return pub, ok
+}
+
+// Verify verifies the security data of b using the public key in keys for
+// the issuer and type of security data of b.
+//
+// The issuer is AirlineDesignatorOfBoardingPassIssuer. If it is empty, the
+// OperatingCarrierDesignator of the first leg is used instead.
+//
+// The signed data is every character that precedes the security section. If
+// b was decoded using FromStr and its fields were not modified since, the
+// original data is used. Otherwise, b is encoded so that a modified b fails
+// verification. SecurityData is the base64 encoded signature. The following
+// public keys are supported:
+//   *ecdsa.PublicKey - ASN.1 encoded signature of the SHA-256 digest
+//   *rsa.PublicKey   - PKCS #1 v1.5 signature of the SHA-256 digest
+//   *dsa.PublicKey   - ASN.1 encoded signature of the SHA-1 digest
+//
+// A *VerifyError is returned if b cannot be verified.
+func Verify(b BCBP, keys KeyStore) error {
+	id := b.keyID()
+	if b.TypeOfSecurityData == "" || b.SecurityData == "" {
+		return MissingSecurityData(id)
+	}
+
+	pub, ok := keys.PublicKey(id)
+	if !ok {
+		return UnknownKey(id)
+	}
+
+	data, err := b.signedData()
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(b.SecurityData)
+	if err != nil {
+		return MalformedSignature(id, "security data must be base64 encoded")
+	}
+
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256([]byte(data))
+		if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+			return InvalidSignature(id)
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256([]byte(data))
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			return InvalidSignature(id)
+		}
+	case *dsa.PublicKey:
+		var rs struct {
+			R, S *big.Int
+		}
+		if rest, err := asn1.Unmarshal(sig, &rs); err != nil || len(rest) > 0 {
+			return MalformedSignature(id, "signature must be an ASN.1 encoded DSA signature")
+		}
+
+		digest := sha1.Sum([]byte(data))
+		if !dsa.Verify(pub, digest[:], rs.R, rs.S) {
+			return InvalidSignature(id)
+		}
+	default:
+		return UnsupportedKey(id, pub)
+	}
+	return nil
+}
+
+// Sign signs b using signer and sets the TypeOfSecurityData and SecurityData
+// of b. The encoded b, including the security section, is returned.
+//
+// The signed data is every character that precedes the security section,
+// i.e. the mandatory and conditional items of b. See SignData for the
+// supported signers.
+func Sign(b *BCBP, signer crypto.Signer, typeOfSecurityData string) (string, error) {
+	data, err := b.encodeData()
+	if err != nil {
+		return "", err
+	}
+
+	section, err := SignData(data, signer, typeOfSecurityData)
+	if err != nil {
+		return "", err
+	}
+
+	// The security section is made up of "^", the type of security data,
+	// the 2 character length of security data, and the security data.
+	b.TypeOfSecurityData = typeOfSecurityData
+	b.SecurityData = section[4:]
+	b.data = data + section
+	b.signedLen = len(data)
+	return b.data, nil
+}
+
+// SignData signs data, the mandatory and conditional items of an encoded Bar
+// Coded Boarding Pass, using signer. The security section made up of "^",
+// typeOfSecurityData, the hex length of the security data, and the security
+// data is returned. It can be appended to data to produce a Bar Coded
+// Boarding Pass that can be verified using Verify.
+//
+// The security data is the base64 encoded signature. The following signers
+// are supported:
+//   *ecdsa.PrivateKey - ASN.1 encoded signature of the SHA-256 digest
+//   *rsa.PrivateKey   - PKCS #1 v1.5 signature of the SHA-256 digest
+// Other signers, such as hardware security modules, are supported as long as
+// their public key is either an *ecdsa.PublicKey or an *rsa.PublicKey.
+//
+// The security data holds at most 255 base64 characters, i.e. signatures of
+// up to 189 bytes. Signers whose signatures may be longer, such as RSA keys
+// of more than 1512 bits, are rejected before signing. Signatures of every
+// standard ECDSA curve, including P-521, fit. A *SignError of type
+// ErrUnsupportedSigner is returned for signers that are rejected.
+func SignData(data string, signer crypto.Signer, typeOfSecurityData string) (string, error) {
+	id := KeyID{TypeOfSecurityData: typeOfSecurityData}
+	pub := signer.Public()
+	n, ok := maxSignatureLength(pub)
+	if !ok {
+		return "", UnsupportedSigner(id, pub)
+	}
+	if base64.StdEncoding.EncodedLen(n) > maxSecurityDataLength {
+		return "", UnsupportedSignerKeySize(id, pub, n)
+	}
+
+	digest := sha256.Sum256([]byte(data))
+	sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+
+	b := BCBP{
+		TypeOfSecurityData: typeOfSecurityData,
+		SecurityData:       base64.StdEncoding.EncodeToString(sig),
+	}
+
+	var sb strings.Builder
+	if err := b.writeSecurity(&sb); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// maxSecurityDataLength is the maximum length of the security data. Its
+// length is encoded as 2 hexadecimal digits.
+const maxSecurityDataLength = 0xFF
+
+// maxSignatureLength returns the length in bytes of the longest signature
+// made using the private key of pub. ok is false if pub is not supported for
+// signing.
+func maxSignatureLength(pub crypto.PublicKey) (n int, ok bool) {
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		// An ASN.1 SEQUENCE of the 2 INTEGERs r and s. They are smaller than
+		// the order of the curve and may need a leading zero byte.
+		size := (pub.Curve.Params().N.BitLen()+7)/8 + 1
+		return derLength(2 * derLength(size)), true
+	case *rsa.PublicKey:
+		return pub.Size(), true
+	}
+	return 0, false
+}
+
+// derLength returns the length of an ASN.1 DER element whose content is n
+// bytes long.
+func derLength(n int) int {
+	switch {
+	case n < 0x80:
+		return 2 + n
+	case n <= 0xFF:
+		return 3 + n
+	}
+	return 4 + n
+}
+
+// keyID returns the KeyID of the public key used to verify b.
+func (b *BCBP) keyID() KeyID {
+	issuer := b.AirlineDesignatorOfBoardingPassIssuer
+	if issuer == "" {
+		issuer = b.Legs[0].OperatingCarrierDesignator
+	}
+	return KeyID{
+		Issuer:             issuer,
+		TypeOfSecurityData: b.TypeOfSecurityData,
+	}
+}
+
+// signedData returns the data that is signed by the security data of b. That
+// is, every character that precedes the security section.
+//
+// The data b was decoded from is only used if it still decodes to the fields
+// of b. Otherwise, b was modified and it is encoded so that the signature is
+// verified against its current fields.
+func (b *BCBP) signedData() (string, error) {
+	if data, n, ok := b.decoded(); ok {
+		return data[:n], nil
+	}
+	return b.encodeData()
+}
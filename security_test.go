@@ -0,0 +1,374 @@
+package bcbp
+
+import (
+	"crypto"
+	"crypto/dsa" //nolint:staticcheck
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+)
+
+const unsignedData = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 100"
+
+// signedPass returns unsignedData followed by a security section holding sig.
+func signedPass(typ string, sig []byte) string {
+	s := base64.StdEncoding.EncodeToString(sig)
+	return fmt.Sprintf("%s^%s%02X%s", unsignedData, typ, len(s), s)
+}
+
+func TestVerify(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(unsignedData))
+	ecdsaSig, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaSig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dsaKey dsa.PrivateKey
+	if err := dsa.GenerateParameters(&dsaKey.Parameters, rand.Reader, dsa.L1024N160); err != nil {
+		t.Fatal(err)
+	}
+	if err := dsa.GenerateKey(&dsaKey, rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	sha1Digest := sha1.Sum([]byte(unsignedData)) //nolint:gosec
+	r, s, err := dsa.Sign(rand.Reader, &dsaKey, sha1Digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	dsaSig, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := KeyMap{
+		{Issuer: "AC", TypeOfSecurityData: "1"}: &ecdsaKey.PublicKey,
+		{Issuer: "AC", TypeOfSecurityData: "2"}: &rsaKey.PublicKey,
+		{Issuer: "AC", TypeOfSecurityData: "3"}: &dsaKey.PublicKey,
+		{Issuer: "AC", TypeOfSecurityData: "4"}: "unsupported",
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want ErrorType
+	}{
+		{
+			name: "ecdsa",
+			in:   signedPass("1", ecdsaSig),
+		},
+		{
+			name: "rsa",
+			in:   signedPass("2", rsaSig),
+		},
+		{
+			name: "dsa",
+			in:   signedPass("3", dsaSig),
+		},
+		{
+			name: "missing security data",
+			in:   unsignedData,
+			want: ErrMissingSecurityData,
+		},
+		{
+			name: "unknown key",
+			in:   signedPass("5", ecdsaSig),
+			want: ErrUnknownKey,
+		},
+		{
+			name: "unsupported key",
+			in:   signedPass("4", ecdsaSig),
+			want: ErrUnknownKey,
+		},
+		{
+			name: "invalid ecdsa signature",
+			in:   signedPass("1", rsaSig),
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "invalid rsa signature",
+			in:   signedPass("2", ecdsaSig),
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "malformed dsa signature",
+			in:   signedPass("3", rsaSig),
+			want: ErrMalformedSignature,
+		},
+		{
+			name: "malformed base64",
+			in:   unsignedData + "^104!!!!",
+			want: ErrMalformedSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromStr(tt.in)
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", tt.in, err)
+			}
+
+			err = Verify(b, keys)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Verify() returned unexpected error: %+v", err)
+				}
+				return
+			}
+
+			var ve *VerifyError
+			if !errors.As(err, &ve) {
+				t.Fatalf("Verify() = %v: expected *VerifyError", err)
+			}
+			if ve.Type != tt.want {
+				t.Errorf("Verify() = %v: expected %s", ve.Type, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify_Modified(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(unsignedData))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := FromStr(unsignedData)
+	if err != nil {
+		t.Fatalf("FromStr(%s) returned unexpected error: %+v", unsignedData, err)
+	}
+	b.TypeOfSecurityData = "1"
+	b.SecurityData = base64.StdEncoding.EncodeToString(sig)
+
+	keys := KeyMap{{Issuer: "AC", TypeOfSecurityData: "1"}: &key.PublicKey}
+	if err := Verify(b, keys); err != nil {
+		t.Errorf("Verify() returned unexpected error: %+v", err)
+	}
+
+	b.Legs[0].SeatNumber = "002A"
+	if err := Verify(b, keys); err == nil {
+		t.Error("Verify() = nil: expected error")
+	}
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := KeyMap{{Issuer: "AC", TypeOfSecurityData: "1"}: &key.PublicKey}
+
+	// The data is not encoded the way Encode would, it holds empty
+	// structured messages. The original data must be verified.
+	const data = "M1DESMARAIS/LUC       EABC123 YULFRAAC 0834 326J001A0025 106>50000"
+	section, err := SignData(data, key, "1")
+	if err != nil {
+		t.Fatalf("SignData() returned unexpected error: %+v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(b *BCBP)
+	}{
+		{name: "passenger name", modify: func(b *BCBP) { b.PassengerName = "DESMARAIS/LUCIE" }},
+		{name: "seat number", modify: func(b *BCBP) { b.Legs[0].SeatNumber = "002A" }},
+		{name: "compartment code", modify: func(b *BCBP) { b.Legs[0].CompartmentCode = "Y" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromStr(data + section)
+			if err != nil {
+				t.Fatalf("FromStr() returned unexpected error: %+v", err)
+			}
+			if err := Verify(b, keys); err != nil {
+				t.Fatalf("Verify() returned unexpected error: %+v", err)
+			}
+
+			tt.modify(&b)
+			if err := Verify(b, keys); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify() = %v, want %v", err, ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		signer crypto.Signer
+		typ    string
+	}{
+		{
+			name:   "ecdsa",
+			signer: ecdsaKey,
+			typ:    "1",
+		},
+		{
+			name:   "rsa",
+			signer: rsaKey,
+			typ:    "2",
+		},
+	}
+
+	keys := KeyMap{
+		{Issuer: "AC", TypeOfSecurityData: "1"}: &ecdsaKey.PublicKey,
+		{Issuer: "AC", TypeOfSecurityData: "2"}: &rsaKey.PublicKey,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromStr(unsignedData)
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", unsignedData, err)
+			}
+
+			s, err := Sign(&b, tt.signer, tt.typ)
+			if err != nil {
+				t.Fatalf("Sign() returned unexpected error: %+v", err)
+			}
+			if err := Verify(b, keys); err != nil {
+				t.Errorf("Verify() returned unexpected error: %+v", err)
+			}
+
+			// The signed boarding pass must be decoded and verified.
+			b, err = FromStr(s)
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", s, err)
+			}
+			if err := Verify(b, keys); err != nil {
+				t.Errorf("Verify() returned unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestSign_Errors(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		signer crypto.Signer
+		typ    string
+	}{
+		{
+			// A 2048 bit RSA signature is 344 base64 characters which
+			// does not fit in the security section.
+			name:   "security data too long",
+			signer: rsaKey,
+			typ:    "1",
+		},
+		{
+			name:   "unsupported signer",
+			signer: unsupportedSigner{},
+			typ:    "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := FromStr(unsignedData)
+			if err != nil {
+				t.Fatalf("FromStr(%s) returned unexpected error: %+v", unsignedData, err)
+			}
+
+			_, err = Sign(&b, tt.signer, tt.typ)
+			var se *SignError
+			if !errors.As(err, &se) || !errors.Is(err, ErrUnsupportedSigner) {
+				t.Errorf("Sign() = %v, want *SignError of type %v", err, ErrUnsupportedSigner)
+			}
+		})
+	}
+}
+
+func TestMaxSignatureLength(t *testing.T) {
+	curves := []elliptic.Curve{elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521()}
+	for _, curve := range curves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			key, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n, ok := maxSignatureLength(&key.PublicKey)
+			if !ok {
+				t.Fatal("maxSignatureLength() = false, want true")
+			}
+			if base64.StdEncoding.EncodedLen(n) > maxSecurityDataLength {
+				t.Errorf("maxSignatureLength() = %d, want signatures to fit in the security data", n)
+			}
+
+			digest := sha256.Sum256([]byte(unsignedData))
+			for i := 0; i < 100; i++ {
+				sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(sig) > n {
+					t.Fatalf("len(Sign()) = %d, want at most %d", len(sig), n)
+				}
+			}
+		})
+	}
+
+	for _, bits := range []int{1512, 1520} {
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = SignData(unsignedData, key, "1")
+		if fits := bits <= 1512; fits != (err == nil) {
+			t.Errorf("SignData() with a %d bit RSA key = %v", bits, err)
+		}
+	}
+}
+
+// unsupportedSigner is a crypto.Signer with an unsupported public key.
+type unsupportedSigner struct{}
+
+func (unsupportedSigner) Public() crypto.PublicKey {
+	return "unsupported"
+}
+
+func (unsupportedSigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("unsupported")
+}
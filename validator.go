@@ -0,0 +1,256 @@
+package bcbp
+
+// charClass is a set of bytes.
+type charClass [4]uint64
+
+// class returns the charClass of the characters in s. Like a bracket
+// expression in a regular expression, a-z denotes every character from a to z.
+// Any other character, including "-" when it does not denote a range, stands
+// for itself.
+func class(s string) charClass {
+	var c charClass
+	for i := 0; i < len(s); i++ {
+		lo, hi := s[i], s[i]
+		if i+2 < len(s) && s[i+1] == '-' {
+			hi = s[i+2]
+			i += 2
+		}
+		for b := int(lo); b <= int(hi); b++ {
+			c[b>>6] |= 1 << (uint(b) & 63)
+		}
+	}
+	return c
+}
+
+// except returns the charClass of every character of c that is not in s.
+func (c charClass) except(s string) charClass {
+	o := class(s)
+	for i := range c {
+		c[i] &^= o[i]
+	}
+	return c
+}
+
+// contains reports whether b is in c.
+func (c charClass) contains(b byte) bool {
+	return c[b>>6]&(1<<(b&63)) != 0
+}
+
+// disjoint reports whether c and o have no characters in common.
+func (c charClass) disjoint(o charClass) bool {
+	for i := range c {
+		if c[i]&o[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// unbounded is the max of a run that matches any number of characters.
+const unbounded = -1
+
+// run matches between min and max consecutive characters of class.
+type run struct {
+	class    charClass
+	min, max int
+}
+
+// pattern is a sequence of runs that must match the whole string.
+//
+// Runs are matched greedily without backtracking. This is only correct if a
+// run whose length may vary has no characters in common with the runs that
+// may follow it. TestValidators_Deterministic checks every pattern.
+type pattern []run
+
+// match reports whether s matches p.
+func (p pattern) match(s string) bool {
+	i := 0
+	for _, r := range p {
+		n := 0
+		for i < len(s) && n != r.max && r.class.contains(s[i]) {
+			i++
+			n++
+		}
+		if n < r.min {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+// fold returns the pattern that matches s ignoring case.
+func fold(s string) pattern {
+	p := make(pattern, len(s))
+	for i := 0; i < len(s); i++ {
+		c := class(s[i : i+1])
+		if lower := s[i] | 0x20; 'a' <= lower && lower <= 'z' {
+			c = class(string([]byte{lower, lower - 'a' + 'A'}))
+		}
+		p[i] = run{c, 1, 1}
+	}
+	return p
+}
+
+// validator matches the strings that match any of its patterns. Empty
+// strings never match.
+type validator []pattern
+
+// match reports whether s matches v.
+func (v validator) match(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, p := range v {
+		if p.match(s) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	digit    = class("0-9")
+	alpha    = class("a-zA-Z")
+	alnum    = class("a-zA-Z0-9")
+	hexDigit = class("a-fA-F0-9")
+	space    = class(" ")
+	anyChar  = class("\x00-\xff").except("\n")
+)
+
+var (
+	formatCodeValidator = validator{
+		{{class("mMsS"), 1, 1}},
+	}
+	numberOfLegsEncodedValidator = validator{
+		{{class("1-4"), 1, 1}},
+	}
+	singleLegNumberOfLegsEncodedValidator = validator{
+		{{class("1"), 1, 1}},
+	}
+	passengerNameValidator = validator{
+		{{class("a-zA-Z "), 0, unbounded}, {class("/"), 1, 1}, {class("a-zA-Z "), 1, unbounded}},
+	}
+	electronicTicketValidator = validator{
+		{{class("eElL"), 1, 1}},
+	}
+	operatingCarrierPNRCodeValidator = validator{
+		{{alnum, 1, unbounded}, {space, 0, unbounded}},
+	}
+	airportCodeValidator = validator{
+		{{alpha, 3, 3}},
+	}
+	operatingCarrierDesignatorValidator = validator{
+		{{alnum, 2, 3}, {space, 0, unbounded}},
+	}
+	flightNumberValidator = validator{
+		{{digit, 4, 4}, {class("a-zA-Z "), 1, 1}},
+	}
+	// Julian dates start at 001 for January 1.
+	dateOfFlightValidator = validator{
+		{{class("0"), 2, 2}, {class("1-9"), 1, 1}},
+		{{class("0"), 1, 1}, {class("1-9"), 1, 1}, {digit, 1, 1}},
+		{{class("1-2"), 1, 1}, {digit, 2, 2}},
+		{{class("3"), 1, 1}, {class("0-5"), 1, 1}, {digit, 1, 1}},
+		{{class("3"), 1, 1}, {class("6"), 1, 1}, {class("0-6"), 1, 1}},
+	}
+	compartmentCodeValidator = validator{
+		{{alpha, 1, 1}},
+	}
+	seatNumberValidator = validator{
+		{{digit, 3, 3}, {alpha, 1, 1}},
+		fold("INF "),
+		fold("GATE"),
+		fold("STBY"),
+	}
+	checkInSequenceNumberValidator = validator{
+		{{digit, 4, 4}, {class("a-zA-Z "), 1, 1}},
+	}
+	passengerStatusValidator = validator{
+		{{alnum, 1, 1}},
+	}
+	hexValidator = validator{
+		{{hexDigit, 2, 2}},
+	}
+	beginningOfVersionNumberValidator = validator{
+		{{class(">"), 1, 1}},
+	}
+	versionNumberValidator = validator{
+		{{class("1-8"), 1, 1}},
+	}
+	passengerDescriptionValidator = validator{
+		{{class("a-zA-Z0-9 "), 1, 1}},
+	}
+	sourceOfCheckInValidator = validator{
+		{{class("WKXRMOTVAwkxrmotva "), 1, 1}},
+	}
+	sourceOfBoardingPassIssuanceValidator = validator{
+		{{class("WKXRMOTVwkxrmotv "), 1, 1}},
+	}
+	dateOfIssueOfBoardingPassValidator = validator{
+		{{digit, 1, 1}, {class("0"), 2, 2}, {class("1-9"), 1, 1}},
+		{{digit, 1, 1}, {class("0"), 1, 1}, {class("1-9"), 1, 1}, {digit, 1, 1}},
+		{{digit, 1, 1}, {class("1-2"), 1, 1}, {digit, 2, 2}},
+		{{digit, 1, 1}, {class("3"), 1, 1}, {class("0-5"), 1, 1}, {digit, 1, 1}},
+		{{digit, 1, 1}, {class("3"), 1, 1}, {class("6"), 1, 1}, {class("0-6"), 1, 1}},
+		{{space, 4, 4}},
+	}
+	documentTypeValidator = validator{
+		{{class("bBiI"), 1, 1}},
+	}
+	airlineDesignatorOfBoardingPassIssuerValidator = validator{
+		{{alnum, 2, 3}, {space, 0, unbounded}},
+		{{space, 3, 3}},
+	}
+	baggageTagLicensePlateNumberValidator = validator{
+		{{class("0-2"), 1, 1}, {digit, 12, 12}},
+		{{space, 13, 13}},
+	}
+	airlineNumericCodeValidator = validator{
+		{{digit, 3, 3}},
+		{{space, 3, 3}},
+	}
+	// Leading zeroes are alphanumeric characters, there is no need to match
+	// them separately.
+	documentFormSerialNumberValidator = validator{
+		{{alnum, 1, unbounded}},
+		{{space, 10, 10}},
+	}
+	selecteeIndicatorValidator = validator{
+		{{class("0-2 "), 1, 1}},
+	}
+	internationalDocumentationVerificationValidator = validator{
+		{{class("0-2 "), 1, 1}},
+	}
+	marketingCarrierDesignatorValidator = validator{
+		{{alnum, 2, 3}, {space, 0, unbounded}},
+		{{space, 3, 3}},
+	}
+	frequentFlyerAirlineDesignatorValidator = validator{
+		{{alnum, 2, 3}, {space, 0, unbounded}},
+		{{space, 3, 3}},
+	}
+	frequentFlyerNumberValidator = validator{
+		{{alnum, 1, unbounded}, {space, 0, unbounded}},
+		{{space, 16, 16}},
+	}
+	idadIndicatorValidator = validator{
+		{{class("a-zA-Z0-9 "), 1, 1}},
+	}
+	freeBaggageAllowanceValidator = validator{
+		{{digit, 2, 2}, {class("kKlL"), 1, 1}},
+		append(pattern{{digit, 1, 1}}, fold("PC")...),
+		{{space, 3, 3}},
+	}
+	fastTrackValidator = validator{
+		{{class("yYnN "), 1, 1}},
+	}
+	anyValidator = validator{
+		{{anyChar, 0, unbounded}},
+	}
+	beginningOfSecurityDataValidator = validator{
+		{{class("^"), 1, 1}},
+	}
+	typeOfSecurityDataValidator = validator{
+		{{alnum, 1, 1}},
+	}
+)
@@ -0,0 +1,26 @@
+//go:build go1.18
+// +build go1.18
+
+package bcbp
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// FuzzValidators checks that the validator of every item matches the same
+// strings as the regular expression it replaced. i selects the item.
+func FuzzValidators(f *testing.F) {
+	refs := references(f)
+	r := rand.New(rand.NewSource(1))
+	for i, ref := range refs {
+		for range ref.item.validator {
+			f.Add(uint8(i), sample(r, ref.item.validator))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, i uint8, s string) {
+		ref := refs[int(i)%len(refs)]
+		checkValidator(t, ref, fit(s, ref.item))
+	})
+}
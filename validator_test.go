@@ -0,0 +1,337 @@
+package bcbp
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// regexStrings are the regular expressions items were validated with before
+// they were replaced by validators, verbatim. The strings that validators
+// match differently on purpose are described by fixes.
+var regexStrings = map[itemID]string{
+	formatCode:                   "^[mMsS]$",
+	numberOfLegsEncoded:          "^[1-4]$",
+	passengerName:                "^[a-zA-Z ]*/[a-zA-Z ]+$",
+	electronicTicketIndicator:    "^[eElL]$",
+	operatingCarrierPNRCode:      "^[a-zA-Z0-9]+ *$",
+	fromCityAirportCode:          "^[a-zA-Z]{3}$",
+	toCityAirportCode:            "^[a-zA-Z]{3}$",
+	operatingCarrierDesignator:   "^[a-zA-Z0-9]{2,3} *$",
+	flightNumber:                 "^[0-9]{4}[a-zA-Z ]{1}$",
+	dateOfFlight:                 "^[0-2][0-9]{2}|3[0-5][0-9]|36[0-6]$",
+	compartmentCode:              "^[a-aA-Z]$",
+	seatNumber:                   "^[0-9]{3}[a-zA-Z]{1}$|^(?i:INF |GATE|STBY)$",
+	checkinSequenceNumber:        "^[0-9]{4}[a-zA-Z ]{1}$",
+	passengerStatus:              "^[a-zA-Z0-9]$",
+	fieldSizeOfVariableSizeField: "^[a-fA-f0-9]{2}$",
+	beginningOfVersionNumber:     "^>$",
+	versionNumber:                "^[1-8]$",
+	fieldSizeOfFollowingStructuredMessageUnique:      "^[a-fA-f0-9]{2}$",
+	passengerDescription:                             "^[a-zA-Z0-9 ]$",
+	sourceOfCheckin:                                  "(?i)^[WKXRMOTVA ]$",
+	sourceOfBoardingPassIssuance:                     "(?i)^[WKXRMOTV ]$",
+	dateOfIssueOfBoardingPass:                        "^[0-9][0-2][0-9]{2}$|^[0-9]3[0-5][0-9]$|^[0-9]36[0-6]$|^ {4}$",
+	documentType:                                     "^[bBiI]$",
+	airlineDesignatorOfBoardingPassIssuer:            "^[a-zA-Z0-9]{2,3} *$|^ {3}$",
+	baggageTagLicensePlateNumber:                     "^[0-2]{1}[0-9]{12}$|^ {13}$",
+	firstNonConsecutiveBaggageTagLicensePlateNumber:  "^[0-2]{1}[0-9]{12}$|^ {13}$",
+	secondNonConsecutiveBaggageTagLicensePlateNumber: "^[0-2]{1}[0-9]{12}$|^ {13}$",
+	fieldSizeOfFollowingStructuredMessageRepeated:    "^[a-fA-f0-9]{2}$",
+	airlineNumericCode:                               "^[0-9]{3}$|^ {3}$",
+	documentFormSerialNumber:                         "^0*[a-zA-Z0-9]*$|^ {10}$",
+	selecteeIndicator:                                "^[0-2]$|^ {1}$",
+	internationalDocumentationVerification:           "^[0-2]$|^ {1}$",
+	marketingCarrierDesignator:                       "^[a-zA-Z0-9]{2,3} *$|^ {3}$",
+	frequentFlyerAirlineDesignator:                   "^[a-zA-Z0-9]{2,3} *$|^ {3}$",
+	frequentFlyerNumber:                              "^[a-zA-Z0-9]+ *$|^ {16}$",
+	idadIndicator:                                    "^[a-zA-Z0-9 ]$",
+	freeBaggageAllowance:                             "^[0-9]{2}[kKlL]|[0-9](?i)(PC)$|^ {3}$",
+	fastTrack:                                        "^[yYnN ]$",
+	forIndividualAirlineUse:                          "^.*$",
+	beginningOfSecurityData:                          "^[\\^]$",
+	typeOfSecurityData:                               "^[a-zA-Z0-9]$",
+	lengthOfSecurityData:                             "^[a-fA-f0-9]{2}$",
+	securityData:                                     "^.*$",
+}
+
+// fixes report, for each item whose validator fixes the regular expression it
+// replaced, whether the validator and the regular expression disagree on s
+// on purpose. s is as long as the item.
+var fixes = map[itemID]func(s string) bool{
+	// The regular expression only accepted "a" as a lower case letter.
+	compartmentCode: func(s string) bool { return s[0] >= 'b' && s[0] <= 'z' },
+
+	// The regular expression of hex numbers accepted every character
+	// between "A" and "f", i.e. the upper case letters past "F" and the
+	// characters "[\]^_`" as well.
+	fieldSizeOfVariableSizeField:                  hexFixed,
+	fieldSizeOfFollowingStructuredMessageUnique:   hexFixed,
+	fieldSizeOfFollowingStructuredMessageRepeated: hexFixed,
+	lengthOfSecurityData:                          hexFixed,
+
+	// The regular expressions of Julian dates accepted day 000.
+	dateOfFlight:              func(s string) bool { return s == "000" },
+	dateOfIssueOfBoardingPass: func(s string) bool { return s[0] >= '0' && s[0] <= '9' && s[1:] == "000" },
+}
+
+// hexFixed reports whether s is matched by the regular expression of hex
+// numbers because it holds a character between "G" and "`".
+func hexFixed(s string) bool {
+	fixed := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+		case c >= 'G' && c <= '`':
+			fixed = true
+		default:
+			return false
+		}
+	}
+	return fixed
+}
+
+// reference is an item and the regular expression it used to be validated
+// with.
+type reference struct {
+	item  item
+	regex *regexp.Regexp
+}
+
+// references returns the reference of every item of spec.
+func references(t testing.TB) []reference {
+	t.Helper()
+
+	refs := []reference{
+		{item: singleLegNumberOfLegsEncoded, regex: regexp.MustCompile("^1$")},
+	}
+	var walk func(items []item)
+	walk = func(items []item) {
+		for _, item := range items {
+			s, ok := regexStrings[item.id]
+			if !ok {
+				t.Fatalf("missing regular expression of %s", item.description)
+			}
+			refs = append(refs, reference{item: item, regex: regexp.MustCompile(s)})
+			walk(item.items)
+		}
+	}
+	walk(spec)
+	return refs
+}
+
+// alphabet holds characters on both sides of the boundaries of the character
+// classes used by validators.
+const alphabet = "\x00\n\x7f\x80 /0235679>@AFGIKLPZ[^`afgiklpz{"
+
+// fit pads s with trailing whitespaces or truncates it to the length of
+// item. It is the only kind of string an item is validated against.
+func fit(s string, item item) string {
+	switch {
+	case item.length == 0:
+		return s
+	case len(s) < item.length:
+		return s + whitespace(item.length-len(s))
+	}
+	return s[:item.length]
+}
+
+// sample returns a random string matching v.
+func sample(r *rand.Rand, v validator) string {
+	var sb strings.Builder
+	for _, run := range v[r.Intn(len(v))] {
+		var chars []byte
+		for b := 0; b < 256; b++ {
+			if run.class.contains(byte(b)) {
+				chars = append(chars, byte(b))
+			}
+		}
+
+		n := run.min
+		if run.max == unbounded {
+			n += r.Intn(8)
+		} else {
+			n += r.Intn(run.max - run.min + 1)
+		}
+		for i := 0; i < n; i++ {
+			sb.WriteByte(chars[r.Intn(len(chars))])
+		}
+	}
+	return sb.String()
+}
+
+// mutate returns s with up to 2 of its characters replaced by characters of
+// alphabet.
+func mutate(r *rand.Rand, s string) string {
+	if s == "" {
+		return s
+	}
+	b := []byte(s)
+	for i := r.Intn(3); i > 0; i-- {
+		b[r.Intn(len(b))] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+// checkValidator checks that the validator of ref matches s if and only if
+// the regular expression of ref does, unless s is one of the fixes of the
+// item.
+func checkValidator(t *testing.T, ref reference, s string) {
+	t.Helper()
+	want := ref.regex.FindString(s) != ""
+	if fixed, ok := fixes[ref.item.id]; ok && fixed(s) {
+		want = !want
+	}
+	if got := ref.item.validate(s); got != want {
+		t.Errorf("%s: validate(%q) = %t, want %t", ref.item.description, s, got, want)
+	}
+}
+
+func TestValidators(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, ref := range references(t) {
+		// Every string of alphabet up to the length of items of at most 3
+		// characters.
+		if ref.item.length <= 3 {
+			strs := []string{""}
+			for i := 0; i < ref.item.length; i++ {
+				var next []string
+				for _, s := range strs {
+					for j := 0; j < len(alphabet); j++ {
+						next = append(next, s+alphabet[j:j+1])
+					}
+				}
+				strs = append(strs, next...)
+			}
+			for _, s := range strs {
+				checkValidator(t, ref, fit(s, ref.item))
+			}
+		}
+
+		for i := 0; i < 1000; i++ {
+			checkValidator(t, ref, fit(mutate(r, sample(r, ref.item.validator)), ref.item))
+		}
+	}
+}
+
+// TestValidators_Deterministic checks that patterns can be matched greedily:
+// a run whose length may vary has no characters in common with the runs that
+// may follow it.
+func TestValidators_Deterministic(t *testing.T) {
+	for _, ref := range references(t) {
+		for _, p := range ref.item.validator {
+			for i, r := range p {
+				if r.min == r.max {
+					continue
+				}
+				for _, next := range p[i+1:] {
+					if !r.class.disjoint(next.class) {
+						t.Errorf("%s: run %d is not disjoint from the runs that follow it", ref.item.description, i)
+					}
+					if next.min > 0 {
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestValidators_Fixed checks the strings that validators match differently
+// than the original regular expressions, and that the original regular
+// expressions indeed matched them the other way around.
+func TestValidators_Fixed(t *testing.T) {
+	tests := []struct {
+		name string
+		id   itemID
+		s    string
+		want bool
+		note string
+	}{
+		{
+			name: "Compartment Code lower case", id: compartmentCode, s: "j", want: true,
+			note: "[a-aA-Z] only accepted a as a lower case letter",
+		},
+		{
+			name: "hex beyond F", id: fieldSizeOfVariableSizeField, s: "GG", want: false,
+			note: "[a-fA-f0-9] accepted every character between A and f",
+		},
+		{
+			name: "hex underscore", id: lengthOfSecurityData, s: "_a", want: false,
+			note: "[a-fA-f0-9] accepted every character between A and f",
+		},
+		{
+			name: "Date of Flight day 0", id: dateOfFlight, s: "000", want: false,
+			note: "[0-2][0-9]{2} accepted day 000",
+		},
+		{
+			name: "Date of Issue of Boarding Pass day 0", id: dateOfIssueOfBoardingPass, s: "2000", want: false,
+			note: "[0-9][0-2][0-9]{2} accepted day 000",
+		},
+		{
+			name: "Date of Flight prefix", id: dateOfFlight, s: "1234", want: false,
+			note: "^[0-2][0-9]{2} is not anchored at the end; validators match whole strings",
+		},
+		{
+			name: "Date of Flight inner", id: dateOfFlight, s: "X350X", want: false,
+			note: "3[0-5][0-9] is not anchored at all; validators match whole strings",
+		},
+		{
+			name: "Free Baggage Allowance prefix", id: freeBaggageAllowance, s: "20KG", want: false,
+			note: "^[0-9]{2}[kKlL] is not anchored at the end; validators match whole strings",
+		},
+		{
+			name: "Free Baggage Allowance suffix", id: freeBaggageAllowance, s: "X2PC", want: false,
+			note: "[0-9](?i)(PC)$ is not anchored at the start; validators match whole strings",
+		},
+	}
+
+	refs := make(map[itemID]reference)
+	for _, ref := range references(t) {
+		refs[ref.item.id] = ref
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref := refs[tt.id]
+			if got := ref.item.validator.match(tt.s); got != tt.want {
+				t.Errorf("match(%q) = %t, want %t: %s", tt.s, got, tt.want, tt.note)
+			}
+			if got := ref.regex.FindString(tt.s) != ""; got == tt.want {
+				t.Errorf("%s: FindString(%q) matched %t, want %t: %s", ref.regex, tt.s, got, !tt.want, tt.note)
+			}
+		})
+	}
+}
+
+// benchmarkSamples returns a valid string for every item along with its
+// reference.
+func benchmarkSamples(b *testing.B) ([]reference, []string) {
+	refs := references(b)
+	r := rand.New(rand.NewSource(1))
+	samples := make([]string, len(refs))
+	for i, ref := range refs {
+		samples[i] = fit(sample(r, ref.item.validator), ref.item)
+	}
+	return refs, samples
+}
+
+func BenchmarkValidate_Regexp(b *testing.B) {
+	refs, samples := benchmarkSamples(b)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i, ref := range refs {
+			ref.regex.FindString(samples[i])
+		}
+	}
+}
+
+func BenchmarkValidate_Validator(b *testing.B) {
+	refs, samples := benchmarkSamples(b)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i, ref := range refs {
+			ref.item.validate(samples[i])
+		}
+	}
+}